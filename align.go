@@ -117,3 +117,84 @@ func Align(plots [][]*Plot, t draw.Tiles, dc draw.Canvas) [][]draw.Canvas {
 	}
 	return o
 }
+
+// alignIterations is the maximum number of refinements made by
+// AlignX and AlignY. The padding of a data canvas depends weakly on
+// the size of the canvas holding it, so a single crop may leave a
+// small residual misalignment.
+const alignIterations = 4
+
+// AlignX returns c cropped so that the DataCanvas of p drawn to the
+// returned canvas spans the same horizontal extent as ref, which is
+// typically the DataCanvas of another plot. Together with ShareX it
+// allows plots to be stacked vertically with exactly aligned X axes.
+func AlignX(p *Plot, c, ref draw.Canvas) draw.Canvas {
+	for i := 0; i < alignIterations; i++ {
+		d := p.DataCanvas(c)
+		left, right := ref.Min.X-d.Min.X, ref.Max.X-d.Max.X
+		if left == 0 && right == 0 {
+			break
+		}
+		c = draw.Crop(c, left, right, 0, 0)
+	}
+	return c
+}
+
+// AlignY returns c cropped so that the DataCanvas of p drawn to the
+// returned canvas spans the same vertical extent as ref, which is
+// typically the DataCanvas of another plot. Together with ShareY it
+// allows plots to be placed side by side with exactly aligned Y axes.
+func AlignY(p *Plot, c, ref draw.Canvas) draw.Canvas {
+	for i := 0; i < alignIterations; i++ {
+		d := p.DataCanvas(c)
+		bottom, top := ref.Min.Y-d.Min.Y, ref.Max.Y-d.Max.Y
+		if bottom == 0 && top == 0 {
+			break
+		}
+		c = draw.Crop(c, 0, 0, bottom, top)
+	}
+	return c
+}
+
+// ShareX sets the X axis range of each of the plots to the union of
+// the X axis ranges of all of the plots, and sets their X axis scale
+// to the scale of the first plot. Nil plots are ignored.
+func ShareX(plots ...*Plot) {
+	axes := make([]*Axis, 0, len(plots))
+	for _, p := range plots {
+		if p != nil {
+			axes = append(axes, &p.X)
+		}
+	}
+	shareAxes(axes)
+}
+
+// ShareY sets the Y axis range of each of the plots to the union of
+// the Y axis ranges of all of the plots, and sets their Y axis scale
+// to the scale of the first plot. Nil plots are ignored.
+func ShareY(plots ...*Plot) {
+	axes := make([]*Axis, 0, len(plots))
+	for _, p := range plots {
+		if p != nil {
+			axes = append(axes, &p.Y)
+		}
+	}
+	shareAxes(axes)
+}
+
+// shareAxes sets the range of each of the axes to the union
+// of their ranges and their scale to that of the first axis.
+func shareAxes(axes []*Axis) {
+	if len(axes) == 0 {
+		return
+	}
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, a := range axes {
+		min = math.Min(min, a.Min)
+		max = math.Max(max, a.Max)
+	}
+	for _, a := range axes {
+		a.Min, a.Max = min, max
+		a.Scale = axes[0].Scale
+	}
+}
@@ -96,3 +96,47 @@ func ExampleAlign() {
 func TestAlign(t *testing.T) {
 	cmpimg.CheckPlot(ExampleAlign, t, "align.png")
 }
+
+func TestAlignXY(t *testing.T) {
+	ref, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ref.X.Min, ref.X.Max = 0, 1
+	ref.Y.Min, ref.Y.Max = 0, 1
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = -10, 2
+	p.Y.Min, p.Y.Max = 1000, 100000
+	p.Y.Label.Text = "wide"
+	p.X.Label.Text = "tall"
+
+	plot.ShareX(ref, p, nil)
+	if p.X.Min != -10 || p.X.Max != 2 || ref.X.Min != -10 || ref.X.Max != 2 {
+		t.Errorf("unexpected shared X ranges: got:[%v, %v] and [%v, %v] want:[-10, 2]",
+			ref.X.Min, ref.X.Max, p.X.Min, p.X.Max)
+	}
+	plot.ShareY(ref, p)
+	if p.Y.Min != 0 || p.Y.Max != 100000 || ref.Y.Min != 0 || ref.Y.Max != 100000 {
+		t.Errorf("unexpected shared Y ranges: got:[%v, %v] and [%v, %v] want:[0, 100000]",
+			ref.Y.Min, ref.Y.Max, p.Y.Min, p.Y.Max)
+	}
+
+	c := draw.New(vgimg.New(300, 300))
+	refC := ref.DataCanvas(draw.Crop(c, 40, -20, 30, -50))
+
+	const tol = 1e-6
+	got := p.DataCanvas(plot.AlignX(p, c, refC))
+	if math.Abs(float64(got.Min.X-refC.Min.X)) > tol || math.Abs(float64(got.Max.X-refC.Max.X)) > tol {
+		t.Errorf("unexpected horizontal extent: got:[%v, %v] want:[%v, %v]",
+			got.Min.X, got.Max.X, refC.Min.X, refC.Max.X)
+	}
+	got = p.DataCanvas(plot.AlignY(p, c, refC))
+	if math.Abs(float64(got.Min.Y-refC.Min.Y)) > tol || math.Abs(float64(got.Max.Y-refC.Max.Y)) > tol {
+		t.Errorf("unexpected vertical extent: got:[%v, %v] want:[%v, %v]",
+			got.Min.Y, got.Max.Y, refC.Min.Y, refC.Max.Y)
+	}
+}
@@ -1,5 +1,7 @@
 module github.com/gshk/plot
 
+go 1.27.1
+
 require (
 	github.com/ajstarks/svgo v0.0.0-20180226025133-644b8db467af
 	github.com/fogleman/gg v1.2.1-0.20190220221249-0403632d5b90
@@ -10,3 +12,5 @@ require (
 	gonum.org/v1/gonum v0.0.0-20180816165407-929014505bf4
 	rsc.io/pdf v0.1.1
 )
+
+require golang.org/x/tools v0.0.0-20180525024113-a5b4c53f6e8b // indirect
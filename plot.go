@@ -137,7 +137,10 @@ func (p *Plot) Add(ps ...Plotter) {
 // added to the plot.  Plotters that  implement the
 // GlyphBoxer interface will have their GlyphBoxes
 // taken into account when padding the plot so that
-// none of their glyphs are clipped.
+// none of their glyphs are clipped. Plotters are
+// clipped to the data area widened by the axis padding,
+// so data outside of the axis ranges is not drawn over
+// the axes, the title or the margins of the canvas.
 func (p *Plot) Draw(c draw.Canvas) {
 	if p.BackgroundColor != nil {
 		c.SetColor(p.BackgroundColor)
@@ -161,13 +164,61 @@ func (p *Plot) Draw(c draw.Canvas) {
 	y.draw(padY(p, draw.Crop(c, 0, 0, xheight, 0)))
 
 	dataC := padY(p, padX(p, draw.Crop(c, ywidth, 0, xheight, 0)))
+	dataC.Push()
+	dataC.Clip(p.clipRectangle(dataC, c.Rectangle).Path())
 	for _, data := range p.plotters {
 		data.Plot(dataC, p)
 	}
+	dataC.Pop()
 
 	p.Legend.Draw(draw.Crop(c, ywidth, 0, xheight, 0))
 }
 
+// clipRectangle returns the rectangle to which plotters drawn
+// on the data canvas c are clipped. It is the data area widened
+// by the axis padding on all sides and extended to contain the
+// glyphs of data within the axis ranges, so that only data
+// outside of the ranges is cut off, limited to the plot area.
+func (p *Plot) clipRectangle(c draw.Canvas, area vg.Rectangle) vg.Rectangle {
+	r := c.Rectangle
+	r.Min.X -= p.Y.Padding
+	r.Max.X += p.Y.Padding
+	r.Min.Y -= p.X.Padding
+	r.Max.Y += p.X.Padding
+	for _, b := range p.GlyphBoxes(p) {
+		if b.Size().X <= 0 || b.Size().Y <= 0 {
+			continue
+		}
+		min := b.Min.Add(vg.Point{X: c.X(b.X), Y: c.Y(b.Y)})
+		max := min.Add(b.Size())
+		if min.X < r.Min.X {
+			r.Min.X = min.X
+		}
+		if min.Y < r.Min.Y {
+			r.Min.Y = min.Y
+		}
+		if max.X > r.Max.X {
+			r.Max.X = max.X
+		}
+		if max.Y > r.Max.Y {
+			r.Max.Y = max.Y
+		}
+	}
+	if r.Min.X < area.Min.X {
+		r.Min.X = area.Min.X
+	}
+	if r.Min.Y < area.Min.Y {
+		r.Min.Y = area.Min.Y
+	}
+	if r.Max.X > area.Max.X {
+		r.Max.X = area.Max.X
+	}
+	if r.Max.Y > area.Max.Y {
+		r.Max.Y = area.Max.Y
+	}
+	return r
+}
+
 // DataCanvas returns a new draw.Canvas that
 // is the subset of the given draw area into which
 // the plot data will be drawn.
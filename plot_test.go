@@ -285,3 +285,92 @@ func TestIssue514(t *testing.T) {
 		})
 	}
 }
+
+func TestDrawClip(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("could not create plot: %v", err)
+	}
+	p.HideAxes()
+	p.BackgroundColor = nil
+	pts, err := plotter.NewScatter(plotter.XYs{{X: 0, Y: 0}, {X: 1, Y: 1}})
+	if err != nil {
+		t.Fatalf("could not create scatter: %v", err)
+	}
+	p.Add(pts)
+
+	var rec recorder.Canvas
+	p.Draw(draw.NewCanvas(&rec, 100, 100))
+
+	var (
+		clip  *recorder.Clip
+		depth int
+	)
+	for _, a := range rec.Actions {
+		switch a := a.(type) {
+		case *recorder.Push:
+			depth++
+		case *recorder.Pop:
+			depth--
+		case *recorder.Clip:
+			clip = a
+			if depth == 0 {
+				t.Errorf("clip outside of Push/Pop")
+			}
+		case *recorder.Fill:
+			if clip == nil {
+				t.Errorf("glyph drawn before clip")
+			}
+		}
+	}
+	if clip == nil {
+		t.Fatal("plotters were not clipped")
+	}
+	// The hidden axes leave their padding at the left and
+	// bottom, and the clip is limited to the canvas.
+	want := vg.Rectangle{
+		Min: vg.Point{X: 2.5, Y: 2.5},
+		Max: vg.Point{X: 100, Y: 100},
+	}.Path()
+	if !reflect.DeepEqual(clip.Path, want) {
+		t.Errorf("unexpected clip path: got:%v want:%v", clip.Path, want)
+	}
+}
+
+func TestDrawClipAxes(t *testing.T) {
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("could not create plot: %v", err)
+	}
+	p.Title.Text = "Title"
+	pts, err := plotter.NewScatter(plotter.XYs{{X: 0, Y: 0}, {X: 10, Y: 10}})
+	if err != nil {
+		t.Fatalf("could not create scatter: %v", err)
+	}
+	p.Add(pts)
+
+	var rec recorder.Canvas
+	c := draw.NewCanvas(&rec, 200, 200)
+	p.Draw(c)
+
+	var clip *recorder.Clip
+	for _, a := range rec.Actions {
+		if a, ok := a.(*recorder.Clip); ok {
+			clip = a
+		}
+	}
+	if clip == nil {
+		t.Fatal("plotters were not clipped")
+	}
+	da := p.DataCanvas(c)
+	want := vg.Rectangle{
+		Min: vg.Point{X: da.Min.X - p.Y.Padding, Y: da.Min.Y - p.X.Padding},
+		Max: vg.Point{X: da.Max.X + p.Y.Padding, Y: da.Max.Y + p.X.Padding},
+	}
+	if !reflect.DeepEqual(clip.Path, want.Path()) {
+		t.Errorf("unexpected clip path: got:%v want:%v", clip.Path, want.Path())
+	}
+	if want.Max.X >= c.Max.X || want.Max.Y >= c.Max.Y {
+		t.Errorf("clip reaches the canvas border: %v", want)
+	}
+}
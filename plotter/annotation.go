@@ -0,0 +1,504 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/tools/bezier"
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/draw"
+)
+
+// AnchorCoords specifies the coordinate system
+// of the location of an Anchor.
+type AnchorCoords int
+
+const (
+	// DataCoords locates an anchor in the data
+	// coordinates of the plot.
+	DataCoords AnchorCoords = iota
+
+	// AxesCoords locates an anchor as a fraction of the
+	// width and height of the data area, with (0, 0) at
+	// the bottom left and (1, 1) at the top right corner.
+	AxesCoords
+
+	// CanvasCoords locates an anchor at a distance in
+	// points from the bottom left corner of the data area.
+	CanvasCoords
+)
+
+// An Anchor is a location on a plot.
+type Anchor struct {
+	// X and Y are the location of the anchor
+	// in the coordinate system given by Coords.
+	X, Y float64
+
+	// Coords is the coordinate system of X and Y.
+	Coords AnchorCoords
+
+	// Offset is added to the location of the anchor
+	// on the canvas.
+	Offset vg.Point
+}
+
+// DataAnchor returns an Anchor at x, y in data coordinates.
+func DataAnchor(x, y float64) Anchor {
+	return Anchor{X: x, Y: y, Coords: DataCoords}
+}
+
+// AxesAnchor returns an Anchor at the fractions x, y
+// of the width and height of the data area.
+func AxesAnchor(x, y float64) Anchor {
+	return Anchor{X: x, Y: y, Coords: AxesCoords}
+}
+
+// CanvasAnchor returns an Anchor at the distances x, y
+// from the bottom left corner of the data area.
+func CanvasAnchor(x, y vg.Length) Anchor {
+	return Anchor{X: float64(x), Y: float64(y), Coords: CanvasCoords}
+}
+
+// point returns the location of the anchor on the data canvas c.
+func (a Anchor) point(c draw.Canvas, trX, trY func(float64) vg.Length) vg.Point {
+	var pt vg.Point
+	switch a.Coords {
+	case DataCoords:
+		pt = vg.Point{X: trX(a.X), Y: trY(a.Y)}
+	case AxesCoords:
+		pt = vg.Point{X: c.X(a.X), Y: c.Y(a.Y)}
+	case CanvasCoords:
+		pt = vg.Point{X: c.Min.X + vg.Length(a.X), Y: c.Min.Y + vg.Length(a.Y)}
+	default:
+		panic("plotter: unknown anchor coordinates")
+	}
+	return pt.Add(a.Offset)
+}
+
+// norm returns the location of the anchor normalized to the
+// data area, as used by plot.GlyphBox, and whether the location
+// can be normalized. Anchors in CanvasCoords cannot.
+func (a Anchor) norm(plt *plot.Plot) (x, y float64, ok bool) {
+	switch a.Coords {
+	case DataCoords:
+		return plt.X.Norm(a.X), plt.Y.Norm(a.Y), true
+	case AxesCoords:
+		return a.X, a.Y, true
+	default:
+		return 0, 0, false
+	}
+}
+
+// visible returns whether the anchor should be drawn. Anchors in
+// DataCoords are only drawn when they are within the data area.
+func (a Anchor) visible(c draw.Canvas, pt vg.Point) bool {
+	return a.Coords != DataCoords || c.Contains(pt.Sub(a.Offset))
+}
+
+// anchorRange updates the data range given by xmin, xmax, ymin and
+// ymax to include the anchors that are in DataCoords.
+func anchorRange(xmin, xmax, ymin, ymax float64, anchors ...Anchor) (float64, float64, float64, float64) {
+	for _, a := range anchors {
+		if a.Coords != DataCoords {
+			continue
+		}
+		xmin, xmax = math.Min(xmin, a.X), math.Max(xmax, a.X)
+		ymin, ymax = math.Min(ymin, a.Y), math.Max(ymax, a.Y)
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// ArrowHead specifies the shape of the head of an arrow.
+type ArrowHead int
+
+const (
+	// NoArrowHead draws no head.
+	NoArrowHead ArrowHead = iota
+
+	// FilledArrowHead draws a filled triangle.
+	FilledArrowHead
+
+	// OpenArrowHead draws two lines
+	// meeting at the tip.
+	OpenArrowHead
+
+	// BarArrowHead draws a line across
+	// the tip.
+	BarArrowHead
+
+	// DotArrowHead draws a filled
+	// circle at the tip.
+	DotArrowHead
+)
+
+// draw draws the arrow head of the given size with its tip at tip,
+// pointing in the direction dir, using the line style sty.
+func (h ArrowHead) draw(c draw.Canvas, sty draw.LineStyle, tip, dir vg.Point, size vg.Length) {
+	l := vg.Length(math.Hypot(float64(dir.X), float64(dir.Y)))
+	if h == NoArrowHead || l == 0 || size <= 0 {
+		return
+	}
+	dir = dir.Scale(1 / l)
+	norm := vg.Point{X: -dir.Y, Y: dir.X}
+	base := tip.Sub(dir.Scale(size))
+	left, right := base.Add(norm.Scale(size/2)), base.Sub(norm.Scale(size/2))
+
+	switch h {
+	case FilledArrowHead:
+		var p vg.Path
+		p.Move(tip)
+		p.Line(left)
+		p.Line(right)
+		p.Close()
+		c.SetColor(sty.Color)
+		c.Fill(p)
+	case OpenArrowHead:
+		c.StrokeLines(sty, []vg.Point{left, tip, right})
+	case BarArrowHead:
+		c.StrokeLine2(sty, tip.X+norm.X*size/2, tip.Y+norm.Y*size/2, tip.X-norm.X*size/2, tip.Y-norm.Y*size/2)
+	case DotArrowHead:
+		c.DrawGlyph(draw.GlyphStyle{Color: sty.Color, Radius: size / 2, Shape: draw.CircleGlyph{}}, tip)
+	default:
+		panic("plotter: unknown arrow head")
+	}
+}
+
+// Annotation implements the Plotter interface, drawing a text box
+// at an anchor, optionally connected to a target anchor by an arrow.
+// Annotations with anchors in DataCoords are only drawn when the
+// anchor is within the data area.
+type Annotation struct {
+	// Text is the text of the annotation. If Text
+	// is empty no text box is drawn and the arrow
+	// starts at At.
+	Text string
+
+	// At is the location of the text box. The box is
+	// placed according to the alignment of TextStyle.
+	At Anchor
+
+	// TextStyle is the style of the annotation text.
+	TextStyle draw.TextStyle
+
+	// Background is the fill color of the text
+	// box. If Background is nil the box is not
+	// filled.
+	Background color.Color
+
+	// Border is the style of the outline of the
+	// text box. If the width of Border is zero no
+	// outline is drawn.
+	Border draw.LineStyle
+
+	// Padding is the distance between the text
+	// and the edges of the text box.
+	Padding vg.Length
+
+	// Target is the location pointed to by the arrow.
+	// If Target is nil no arrow is drawn.
+	Target *Anchor
+
+	// ArrowStyle is the line style of the arrow.
+	ArrowStyle draw.LineStyle
+
+	// Head is the shape of the arrow head.
+	Head ArrowHead
+
+	// HeadSize is the length of the arrow head.
+	HeadSize vg.Length
+
+	// Curvature bends the arrow into a curve. The
+	// control point of the curve is displaced to the
+	// left of the straight arrow by Curvature times
+	// its length. Negative values bend the arrow to
+	// the right.
+	Curvature float64
+}
+
+// NewAnnotation returns an Annotation with the given text at the
+// given anchor, using the default font and line styles. The text
+// is centered on the anchor, and no arrow is drawn until a Target
+// is set.
+func NewAnnotation(text string, at Anchor) (*Annotation, error) {
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Annotation{
+		Text: text,
+		At:   at,
+		TextStyle: draw.TextStyle{
+			Font:   fnt,
+			XAlign: draw.XCenter,
+			YAlign: draw.YCenter,
+		},
+		Background: color.White,
+		Border:     DefaultLineStyle,
+		Padding:    vg.Points(3),
+		ArrowStyle: DefaultLineStyle,
+		Head:       FilledArrowHead,
+		HeadSize:   vg.Points(6),
+	}, nil
+}
+
+// box returns the text box of the annotation relative to its anchor.
+func (a *Annotation) box() vg.Rectangle {
+	r := a.TextStyle.Rectangle(a.Text)
+	r.Min = r.Min.Sub(vg.Point{X: a.Padding, Y: a.Padding - a.TextStyle.Font.Extents().Descent})
+	r.Max = r.Max.Add(vg.Point{X: a.Padding, Y: a.Padding})
+	return r
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (a *Annotation) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	at := a.At.point(c, trX, trY)
+	if !a.At.visible(c, at) {
+		return
+	}
+
+	var box vg.Rectangle
+	if a.Text != "" {
+		box = a.box()
+		box.Min = box.Min.Add(at)
+		box.Max = box.Max.Add(at)
+	} else {
+		box = vg.Rectangle{Min: at, Max: at}
+	}
+
+	if a.Target != nil {
+		a.drawArrow(c, box, a.Target.point(c, trX, trY))
+	}
+
+	if a.Text == "" {
+		return
+	}
+	if a.Background != nil {
+		c.SetColor(a.Background)
+		c.Fill(box.Path())
+	}
+	if a.Border.Width > 0 {
+		c.SetLineStyle(a.Border)
+		c.Stroke(box.Path())
+	}
+	c.FillText(a.TextStyle, at, a.Text)
+}
+
+// curveSegments is the number of segments used
+// to draw curved arrows.
+const curveSegments = 32
+
+// drawArrow draws the arrow from the edge of the text box
+// to the target point.
+func (a *Annotation) drawArrow(c draw.Canvas, box vg.Rectangle, target vg.Point) {
+	center := vg.Point{X: (box.Min.X + box.Max.X) / 2, Y: (box.Min.Y + box.Max.Y) / 2}
+	if box.Min != box.Max && inRect(box, target) {
+		// The target is hidden by the text box.
+		return
+	}
+	d := target.Sub(center)
+	var pts []vg.Point
+	if a.Curvature == 0 {
+		pts = []vg.Point{boxEdge(box, center, target), target}
+	} else {
+		ctrl := center.Add(d.Scale(0.5)).Add(vg.Point{X: -d.Y, Y: d.X}.Scale(vg.Length(a.Curvature)))
+		pts = bezier.New(boxEdge(box, center, ctrl), ctrl, target).Curve(make([]vg.Point, curveSegments+1))
+	}
+	c.StrokeLines(a.ArrowStyle, pts)
+	a.Head.draw(c, a.ArrowStyle, target, target.Sub(pts[len(pts)-2]), a.HeadSize)
+}
+
+// boxEdge returns the point where the line from the center of
+// the rectangle r towards pt leaves r.
+func boxEdge(r vg.Rectangle, center, pt vg.Point) vg.Point {
+	d := pt.Sub(center)
+	half := r.Size().Scale(0.5)
+	t := math.Inf(1)
+	if d.X != 0 {
+		t = math.Min(t, math.Abs(float64(half.X/d.X)))
+	}
+	if d.Y != 0 {
+		t = math.Min(t, math.Abs(float64(half.Y/d.Y)))
+	}
+	if math.IsInf(t, 1) || t > 1 {
+		return center
+	}
+	return center.Add(d.Scale(vg.Length(t)))
+}
+
+// inRect returns whether pt is within the rectangle r.
+func inRect(r vg.Rectangle, pt vg.Point) bool {
+	return pt.X >= r.Min.X && pt.X <= r.Max.X && pt.Y >= r.Min.Y && pt.Y <= r.Max.Y
+}
+
+// DataRange implements the DataRange method of the plot.DataRanger
+// interface, returning the range of the anchors in DataCoords.
+func (a *Annotation) DataRange() (xmin, xmax, ymin, ymax float64) {
+	anchors := []Anchor{a.At}
+	if a.Target != nil {
+		anchors = append(anchors, *a.Target)
+	}
+	return anchorRange(math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1), anchors...)
+}
+
+// GlyphBoxes implements the GlyphBoxes method of the
+// plot.GlyphBoxer interface, returning the text box and
+// the arrow head when their anchors are not in
+// CanvasCoords.
+func (a *Annotation) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	var boxes []plot.GlyphBox
+	if x, y, ok := a.At.norm(plt); ok && a.Text != "" {
+		r := a.box()
+		r.Min = r.Min.Add(a.At.Offset)
+		r.Max = r.Max.Add(a.At.Offset)
+		boxes = append(boxes, plot.GlyphBox{X: x, Y: y, Rectangle: r})
+	}
+	if a.Target == nil || a.Head == NoArrowHead {
+		return boxes
+	}
+	if x, y, ok := a.Target.norm(plt); ok {
+		off := a.Target.Offset
+		boxes = append(boxes, plot.GlyphBox{
+			X: x, Y: y,
+			Rectangle: vg.Rectangle{
+				Min: off.Sub(vg.Point{X: a.HeadSize, Y: a.HeadSize}),
+				Max: off.Add(vg.Point{X: a.HeadSize, Y: a.HeadSize}),
+			},
+		})
+	}
+	return boxes
+}
+
+// Bracket implements the Plotter interface, drawing a square
+// bracket spanning two anchors with an optional label.
+type Bracket struct {
+	// From and To are the ends of the bracket.
+	From, To Anchor
+
+	// Depth is the length of the ends of the bracket.
+	// The bracket opens to the right of the direction
+	// from From to To, so that a bracket from left to
+	// right opens downwards. A negative Depth reverses
+	// the bracket.
+	Depth vg.Length
+
+	// LineStyle is the style of the bracket.
+	LineStyle draw.LineStyle
+
+	// Text is the label of the bracket, drawn
+	// outside the middle of the bracket.
+	Text string
+
+	// TextStyle is the style of the label. The
+	// alignment of the label is set according to
+	// the direction of the bracket.
+	TextStyle draw.TextStyle
+
+	// Gap is the distance between the bracket
+	// and its label.
+	Gap vg.Length
+}
+
+// NewBracket returns a Bracket spanning from and to with the given
+// label, using the default font and line style.
+func NewBracket(from, to Anchor, text string) (*Bracket, error) {
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Bracket{
+		From:      from,
+		To:        to,
+		Depth:     vg.Points(5),
+		LineStyle: DefaultLineStyle,
+		Text:      text,
+		TextStyle: draw.TextStyle{Font: fnt},
+		Gap:       vg.Points(2),
+	}, nil
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (b *Bracket) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	from, to := b.From.point(c, trX, trY), b.To.point(c, trX, trY)
+	if !b.From.visible(c, from) || !b.To.visible(c, to) {
+		return
+	}
+	d := to.Sub(from)
+	l := vg.Length(math.Hypot(float64(d.X), float64(d.Y)))
+	if l == 0 {
+		return
+	}
+	// out points away from the opening of the bracket.
+	out := vg.Point{X: -d.Y / l, Y: d.X / l}
+	c.StrokeLines(b.LineStyle, []vg.Point{
+		from.Sub(out.Scale(b.Depth)),
+		from,
+		to,
+		to.Sub(out.Scale(b.Depth)),
+	})
+
+	if b.Text == "" {
+		return
+	}
+	if b.Depth < 0 {
+		out = out.Scale(-1)
+	}
+	sty := b.TextStyle
+	sty.XAlign = draw.XAlignment(-0.5 + 0.5*float64(out.X))
+	sty.YAlign = draw.YAlignment(-0.5 + 0.5*float64(out.Y))
+	mid := from.Add(d.Scale(0.5))
+	c.FillText(sty, mid.Add(out.Scale(b.Gap)), b.Text)
+}
+
+// DataRange implements the DataRange method of the plot.DataRanger
+// interface, returning the range of the anchors in DataCoords.
+func (b *Bracket) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return anchorRange(math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1), b.From, b.To)
+}
+
+// GlyphBoxes implements the GlyphBoxes method of the
+// plot.GlyphBoxer interface. The ends of the bracket
+// and its label are given boxes that extend by the
+// depth of the bracket, or by the size of the label
+// and the gap, in every direction, since the
+// direction of the bracket depends on the size of
+// the canvas.
+func (b *Bracket) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	depth := vg.Length(math.Abs(float64(b.Depth)))
+	ext := vg.Point{X: depth, Y: depth}
+	var boxes []plot.GlyphBox
+	var mx, my float64
+	n := 0
+	for _, a := range []Anchor{b.From, b.To} {
+		x, y, ok := a.norm(plt)
+		if !ok {
+			continue
+		}
+		boxes = append(boxes, plot.GlyphBox{
+			X: x, Y: y,
+			Rectangle: vg.Rectangle{Min: a.Offset.Sub(ext), Max: a.Offset.Add(ext)},
+		})
+		mx += x
+		my += y
+		n++
+	}
+	if b.Text == "" || n != 2 {
+		return boxes
+	}
+	w, h := b.TextStyle.Width(b.Text), b.TextStyle.Height(b.Text)
+	size := vg.Length(math.Max(float64(w), float64(h))) + b.Gap
+	off := b.From.Offset.Add(b.To.Offset).Scale(0.5)
+	return append(boxes, plot.GlyphBox{
+		X: mx / 2, Y: my / 2,
+		Rectangle: vg.Rectangle{
+			Min: off.Sub(vg.Point{X: size, Y: size}),
+			Max: off.Add(vg.Point{X: size, Y: size}),
+		},
+	})
+}
@@ -0,0 +1,139 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter_test
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/cmpimg"
+	"github.com/gshk/plot/plotter"
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/draw"
+	"github.com/gshk/plot/vg/recorder"
+)
+
+// ExampleAnnotation draws a damped oscillation with callouts
+// pointing at its first peak and trough, and a bracket marking
+// its period.
+func ExampleAnnotation() {
+	f := plotter.NewFunction(func(x float64) float64 {
+		return math.Exp(-x/4) * math.Cos(x)
+	})
+	f.Samples = 200
+
+	peak, err := plotter.NewAnnotation("first peak", plotter.DataAnchor(2, 1))
+	if err != nil {
+		log.Panic(err)
+	}
+	peak.Target = &plotter.Anchor{X: 0, Y: 1}
+
+	trough, err := plotter.NewAnnotation("first trough", plotter.DataAnchor(5, -0.6))
+	if err != nil {
+		log.Panic(err)
+	}
+	trough.Target = &plotter.Anchor{X: math.Pi, Y: -math.Exp(-math.Pi / 4)}
+	trough.Head = plotter.OpenArrowHead
+	trough.Curvature = 0.3
+
+	note, err := plotter.NewAnnotation("damped", plotter.AxesAnchor(1, 1))
+	if err != nil {
+		log.Panic(err)
+	}
+	note.TextStyle.XAlign, note.TextStyle.YAlign = draw.XRight, draw.YTop
+	note.Border.Width = 0
+
+	period, err := plotter.NewBracket(
+		plotter.DataAnchor(0, -0.9),
+		plotter.DataAnchor(2*math.Pi, -0.9),
+		"period",
+	)
+	if err != nil {
+		log.Panic(err)
+	}
+	period.Depth = -period.Depth
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = -1, 1
+	p.Add(f, peak, trough, note, period)
+
+	err = p.Save(300, 200, "testdata/annotation.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestAnnotationExample(t *testing.T) {
+	cmpimg.CheckPlot(ExampleAnnotation, t, "annotation.png")
+}
+
+func TestAnnotation(t *testing.T) {
+	a, err := plotter.NewAnnotation("text", plotter.DataAnchor(1, 2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a.Target = &plotter.Anchor{X: -1, Y: 5}
+
+	xmin, xmax, ymin, ymax := a.DataRange()
+	if xmin != -1 || xmax != 1 || ymin != 2 || ymax != 5 {
+		t.Errorf("unexpected data range: got:%v %v %v %v want:-1 1 2 5", xmin, xmax, ymin, ymax)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(a)
+	if got := len(a.GlyphBoxes(p)); got != 2 {
+		t.Errorf("unexpected number of glyph boxes: got:%d want:2", got)
+	}
+
+	a.At = plotter.CanvasAnchor(vg.Points(10), vg.Points(10))
+	a.Head = plotter.NoArrowHead
+	if got := len(a.GlyphBoxes(p)); got != 0 {
+		t.Errorf("unexpected number of glyph boxes for canvas anchor without head: got:%d want:0", got)
+	}
+
+	// The text box hides the target, so only the box
+	// and the text are drawn.
+	a.Target = &plotter.Anchor{X: 10, Y: 10, Coords: plotter.CanvasCoords}
+	var c recorder.Canvas
+	p.Draw(draw.NewCanvas(&c, 300, 200))
+	var texts int
+	for _, act := range c.Actions {
+		if _, ok := act.(*recorder.FillString); ok {
+			texts++
+		}
+	}
+	if texts == 0 {
+		t.Error("annotation text not drawn")
+	}
+}
+
+func TestBracket(t *testing.T) {
+	b, err := plotter.NewBracket(plotter.DataAnchor(0, 0), plotter.AxesAnchor(1, 0), "label")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	xmin, xmax, ymin, ymax := b.DataRange()
+	if xmin != 0 || xmax != 0 || ymin != 0 || ymax != 0 {
+		t.Errorf("unexpected data range: got:%v %v %v %v want:0 0 0 0", xmin, xmax, ymin, ymax)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(b)
+	if got := len(b.GlyphBoxes(p)); got != 3 {
+		t.Errorf("unexpected number of glyph boxes: got:%d want:3", got)
+	}
+}
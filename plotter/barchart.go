@@ -25,6 +25,16 @@ type BarChart struct {
 	// Color is the fill color of the bars.
 	Color color.Color
 
+	// Gradient, if not empty, holds the stops of a
+	// linear gradient that fills each bar in place of
+	// Color, from offset 0 at the base of the bar to
+	// offset 1 at its top.
+	Gradient []vg.GradientStop
+
+	// Hatch is the hatch pattern drawn over the
+	// fill of the bars. The zero Hatch draws nothing.
+	Hatch vg.Hatch
+
 	// LineStyle is the style of the outline of the bars.
 	draw.LineStyle
 
@@ -130,6 +140,7 @@ func (b *BarChart) Plot(c draw.Canvas, plt *plot.Plot) {
 
 		var pts []vg.Point
 		var poly []vg.Point
+		var top vg.Point
 		if !b.Horizontal {
 			pts = []vg.Point{
 				{catMin, valMin},
@@ -138,6 +149,7 @@ func (b *BarChart) Plot(c draw.Canvas, plt *plot.Plot) {
 				{catMax, valMin},
 			}
 			poly = c.ClipPolygonY(pts)
+			top = vg.Point{X: catMin, Y: valMax}
 		} else {
 			pts = []vg.Point{
 				{valMin, catMin},
@@ -146,8 +158,19 @@ func (b *BarChart) Plot(c draw.Canvas, plt *plot.Plot) {
 				{valMax, catMin},
 			}
 			poly = c.ClipPolygonX(pts)
+			top = vg.Point{X: valMax, Y: catMin}
 		}
-		c.FillPolygon(b.Color, poly)
+		if len(b.Gradient) != 0 {
+			c.FillPolygonGradient(vg.Gradient{
+				Kind:  vg.LinearGradient,
+				Start: pts[0],
+				End:   top,
+				Stops: b.Gradient,
+			}, poly)
+		} else {
+			c.FillPolygon(b.Color, poly)
+		}
+		c.FillPolygonHatch(b.Hatch, poly)
 
 		var outline [][]vg.Point
 		if !b.Horizontal {
@@ -214,7 +237,17 @@ func (b *BarChart) Thumbnail(c *draw.Canvas) {
 		{c.Max.X, c.Min.Y},
 	}
 	poly := c.ClipPolygonY(pts)
-	c.FillPolygon(b.Color, poly)
+	if len(b.Gradient) != 0 {
+		c.FillPolygonGradient(vg.Gradient{
+			Kind:  vg.LinearGradient,
+			Start: pts[0],
+			End:   pts[1],
+			Stops: b.Gradient,
+		}, poly)
+	} else {
+		c.FillPolygon(b.Color, poly)
+	}
+	c.FillPolygonHatch(b.Hatch, poly)
 
 	pts = append(pts, vg.Point{X: c.Min.X, Y: c.Min.Y})
 	outline := c.ClipLinesY(pts)
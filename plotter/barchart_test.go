@@ -5,6 +5,7 @@
 package plotter_test
 
 import (
+	"fmt"
 	"image/color"
 	"log"
 	"testing"
@@ -274,3 +275,93 @@ func ExampleBarChart_positiveNegative() {
 func TestBarChart_positiveNegative(t *testing.T) {
 	cmpimg.CheckPlot(ExampleBarChart_positiveNegative, t, "barChart_positiveNegative.png")
 }
+
+// This example shows grouped bars told apart by hatch
+// patterns, so that they remain distinct when printed
+// in black and white.
+func ExampleBarChart_hatch() {
+	groups := []plotter.Values{
+		{20, 35, 30, 35, 27},
+		{25, 32, 34, 20, 25},
+		{12, 28, 15, 21, 8},
+	}
+	patterns := []vg.HatchPattern{vg.DiagonalHatch, vg.CrossHatch, vg.DotHatch}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Hatched bar chart"
+	p.Y.Label.Text = "Heights"
+
+	w := vg.Points(16)
+	for i, g := range groups {
+		bars, err := plotter.NewBarChart(g, w)
+		if err != nil {
+			log.Panic(err)
+		}
+		bars.Color = color.White
+		bars.Hatch = vg.Hatch{
+			Pattern: patterns[i],
+			Spacing: vg.Points(4),
+			Width:   vg.Points(1),
+		}
+		bars.Offset = w * vg.Length(i-1)
+		bars.Interval = 1
+		p.Add(bars)
+		p.Legend.Add(fmt.Sprintf("Group %c", 'A'+i), bars)
+	}
+	p.Legend.Top = true
+	p.NominalX("One", "Two", "Three", "Four", "Five")
+
+	err = p.Save(300, 250, "testdata/hatchBarChart.png")
+	if err != nil {
+		log.Panic(err)
+	}
+
+	// The vgsvg backend fills the bars with native SVG patterns
+	// and the vgpdf backend clips the drawn hatching to the bars.
+	err = p.Save(300, 250, "testdata/hatchBarChart.svg")
+	if err != nil {
+		log.Panic(err)
+	}
+	err = p.Save(300, 250, "testdata/hatchBarChart.pdf")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestBarChartHatch(t *testing.T) {
+	cmpimg.CheckPlot(ExampleBarChart_hatch, t, "hatchBarChart.png", "hatchBarChart.svg", "hatchBarChart.pdf")
+}
+
+// This example shows bars filled with a gradient from
+// their base to their top.
+func ExampleBarChart_gradient() {
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Gradient bar chart"
+
+	bars, err := plotter.NewBarChart(plotter.Values{3, 5, 8, 6, 2}, vg.Points(30))
+	if err != nil {
+		log.Panic(err)
+	}
+	bars.Interval = 1
+	bars.Gradient = []vg.GradientStop{
+		{Offset: 0, Color: color.NRGBA{R: 255, G: 224, B: 128, A: 255}},
+		{Offset: 1, Color: color.NRGBA{R: 192, G: 32, B: 0, A: 255}},
+	}
+	p.Add(bars)
+	p.NominalX("A", "B", "C", "D", "E")
+
+	err = p.Save(250, 200, "testdata/gradientBarChart.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestBarChartGradient(t *testing.T) {
+	cmpimg.CheckPlot(ExampleBarChart_gradient, t, "gradientBarChart.png")
+}
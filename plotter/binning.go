@@ -0,0 +1,215 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// BinRule is a rule for choosing the number of equal width
+// bins of a histogram from the distribution of the sample.
+// Each x value is counted as a single observation by the
+// rules, irrespective of its weight.
+type BinRule int
+
+const (
+	// SqrtRule uses the square root of the
+	// number of observations.
+	SqrtRule BinRule = iota
+
+	// SturgesRule uses log2(n)+1 bins, which
+	// suits samples from normal distributions of
+	// moderate size.
+	SturgesRule
+
+	// ScottRule uses bins of width 3.49σn^(-1/3),
+	// which is optimal for normal distributions.
+	ScottRule
+
+	// FreedmanDiaconisRule uses bins of width
+	// 2·IQR·n^(-1/3), which is robust to outliers.
+	FreedmanDiaconisRule
+)
+
+// Bins returns the number of bins given by the rule for the values
+// vs. The returned number is at least one.
+func (r BinRule) Bins(vs Valuer) int {
+	xs := finiteValues(vs)
+	n := float64(len(xs))
+	if n < 2 {
+		return 1
+	}
+	var bins float64
+	switch r {
+	case SqrtRule:
+		bins = math.Ceil(math.Sqrt(n))
+	case SturgesRule:
+		bins = math.Ceil(math.Log2(n)) + 1
+	case ScottRule, FreedmanDiaconisRule:
+		var width float64
+		if r == ScottRule {
+			width = 3.49 * stdDev(xs) * math.Cbrt(1/n)
+		} else {
+			sort.Float64s(xs)
+			width = 2 * (quantile(0.75, xs) - quantile(0.25, xs)) * math.Cbrt(1/n)
+		}
+		min, max := xs[0], xs[0]
+		for _, x := range xs {
+			min = math.Min(min, x)
+			max = math.Max(max, x)
+		}
+		if width == 0 {
+			return 1
+		}
+		bins = math.Ceil((max - min) / width)
+	default:
+		panic("plotter: unknown bin rule")
+	}
+	if bins < 1 {
+		return 1
+	}
+	return int(bins)
+}
+
+// finiteValues returns the finite values of vs.
+func finiteValues(vs Valuer) []float64 {
+	xs := make([]float64, 0, vs.Len())
+	for i := 0; i < vs.Len(); i++ {
+		x := vs.Value(i)
+		if !math.IsNaN(x) && !math.IsInf(x, 0) {
+			xs = append(xs, x)
+		}
+	}
+	return xs
+}
+
+// stdDev returns the sample standard deviation of xs.
+func stdDev(xs []float64) float64 {
+	var mean float64
+	for _, x := range xs {
+		mean += x
+	}
+	mean /= float64(len(xs))
+	var ss float64
+	for _, x := range xs {
+		ss += (x - mean) * (x - mean)
+	}
+	return math.Sqrt(ss / float64(len(xs)-1))
+}
+
+// quantile returns the p quantile of the sorted values xs,
+// interpolating linearly between order statistics.
+func quantile(p float64, xs []float64) float64 {
+	pos := p * float64(len(xs)-1)
+	i := int(pos)
+	if i+1 >= len(xs) {
+		return xs[len(xs)-1]
+	}
+	f := pos - float64(i)
+	return xs[i]*(1-f) + xs[i+1]*f
+}
+
+// LinearEdges returns the n+1 edges of n bins of equal
+// width spanning [min, max].
+func LinearEdges(min, max float64, n int) []float64 {
+	edges := make([]float64, n+1)
+	for i := range edges {
+		edges[i] = min + (max-min)*float64(i)/float64(n)
+	}
+	edges[n] = max
+	return edges
+}
+
+// LogEdges returns the n+1 edges of n bins of equal width
+// on a logarithmic scale spanning [min, max], for histograms
+// drawn on a plot.LogScale axis. Both min and max must be
+// positive.
+func LogEdges(min, max float64, n int) []float64 {
+	edges := make([]float64, n+1)
+	for i := range edges {
+		edges[i] = min * math.Pow(max/min, float64(i)/float64(n))
+	}
+	edges[0], edges[n] = min, max
+	return edges
+}
+
+// SharedEdges returns the edges of equal width bins spanning all of
+// the values of vs, with the number of bins given by the rule r
+// applied to the pooled values. Histograms created with the edges by
+// NewHistogramEdges or NewHistEdges share their bins so that they can
+// be overlaid or compared.
+func SharedEdges(r BinRule, vs ...Valuer) ([]float64, error) {
+	var pooled Values
+	for _, v := range vs {
+		pooled = append(pooled, finiteValues(v)...)
+	}
+	if len(pooled) == 0 {
+		return nil, ErrNoData
+	}
+	min, max := Range(pooled)
+	n := r.Bins(pooled)
+	if max == min {
+		max = min + 1
+	}
+	return LinearEdges(min, max, n), nil
+}
+
+// checkEdges returns an error if edges does not hold at
+// least two finite values in increasing order.
+func checkEdges(edges []float64) error {
+	if len(edges) < 2 {
+		return errors.New("plotter: histogram needs at least two bin edges")
+	}
+	if err := CheckFinite(edges...); err != nil {
+		return err
+	}
+	for i := 1; i < len(edges); i++ {
+		if edges[i] <= edges[i-1] {
+			return errors.New("plotter: histogram bin edges not increasing")
+		}
+	}
+	return nil
+}
+
+// binEdges returns the bins delimited by edges holding the weights
+// of xys. Each bin includes its lower edge, and the last bin also
+// includes its upper edge. Values outside the edges are discarded.
+func binEdges(xys XYer, edges []float64) []HistogramBin {
+	bins := make([]HistogramBin, len(edges)-1)
+	for i := range bins {
+		bins[i].Min = edges[i]
+		bins[i].Max = edges[i+1]
+	}
+	last := edges[len(edges)-1]
+	for i := 0; i < xys.Len(); i++ {
+		x, y := xys.XY(i)
+		if !(x >= edges[0] && x <= last) {
+			continue
+		}
+		bin := sort.SearchFloat64s(edges, x)
+		if edges[bin] != x {
+			bin--
+		}
+		if bin == len(bins) {
+			bin--
+		}
+		bins[bin].Weight += y
+	}
+	return bins
+}
+
+// uniformWidth returns the common width of the bins delimited by
+// edges, or zero if the widths differ.
+func uniformWidth(edges []float64) float64 {
+	w := edges[1] - edges[0]
+	for i := 2; i < len(edges); i++ {
+		if math.Abs(edges[i]-edges[i-1]-w) > 1e-9*math.Abs(w) {
+			return 0
+		}
+	}
+	return w
+}
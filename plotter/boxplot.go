@@ -40,10 +40,110 @@ type fiveStatPlot struct {
 	// Min and Max are the extreme values of the data.
 	Min, Max float64
 
+	// Mean is the mean value of the data.
+	Mean float64
+
+	// N is the number of values summarized
+	// by the plot.
+	N int
+
 	// Outside are the indices of Vs for the outside points.
 	Outside []int
 }
 
+// WhiskerRule is a rule for the extent of the whiskers
+// of a box plot. Values beyond the whiskers are drawn as
+// outside points.
+type WhiskerRule interface {
+	// Whiskers returns the ends of the whiskers
+	// for the sorted values with the given first
+	// and third quartiles.
+	Whiskers(sorted []float64, q1, q3 float64) (low, high float64)
+}
+
+// IQRWhiskers is a WhiskerRule that extends the whiskers to the
+// most extreme values within fences placed the given multiple of
+// the interquartile range beyond the quartiles. Tukey's rule is
+// IQRWhiskers(1.5).
+type IQRWhiskers float64
+
+// Whiskers implements the WhiskerRule interface.
+func (r IQRWhiskers) Whiskers(sorted []float64, q1, q3 float64) (low, high float64) {
+	lowFence := q1 - float64(r)*(q3-q1)
+	highFence := q3 + float64(r)*(q3-q1)
+	low, high = math.Inf(1), math.Inf(-1)
+	for _, v := range sorted {
+		if v < lowFence || v > highFence {
+			continue
+		}
+		low = math.Min(low, v)
+		high = math.Max(high, v)
+	}
+	return low, high
+}
+
+// MinMaxWhiskers is a WhiskerRule that extends the whiskers to
+// the extreme values, so that there are no outside points.
+type MinMaxWhiskers struct{}
+
+// Whiskers implements the WhiskerRule interface.
+func (MinMaxWhiskers) Whiskers(sorted []float64, _, _ float64) (low, high float64) {
+	return sorted[0], sorted[len(sorted)-1]
+}
+
+// PercentileWhiskers is a WhiskerRule that extends the whiskers
+// to the Low and High percentiles of the values, given in the range
+// [0, 100] and interpolated between values.
+type PercentileWhiskers struct {
+	Low, High float64
+}
+
+// Whiskers implements the WhiskerRule interface.
+func (r PercentileWhiskers) Whiskers(sorted []float64, _, _ float64) (low, high float64) {
+	return quantile(r.Low/100, sorted), quantile(r.High/100, sorted)
+}
+
+// checkWhiskers returns an error if the parameters
+// of a built-in WhiskerRule are not valid.
+func checkWhiskers(r WhiskerRule) error {
+	switch r := r.(type) {
+	case IQRWhiskers:
+		if !(r >= 0) {
+			return errors.New("plotter: negative interquartile range multiple")
+		}
+	case PercentileWhiskers:
+		if !(0 <= r.Low && r.Low <= r.High && r.High <= 100) {
+			return errors.New("plotter: whisker percentiles out of order or outside [0, 100]")
+		}
+	}
+	return nil
+}
+
+// BoxPlotStats are the precomputed summary statistics
+// of a distribution of values drawn by a BoxPlot.
+type BoxPlotStats struct {
+	// Median, Quartile1 and Quartile3 are the
+	// median and the first and third quartiles.
+	Median, Quartile1, Quartile3 float64
+
+	// AdjLow and AdjHigh are the values to
+	// which the whiskers are drawn.
+	AdjLow, AdjHigh float64
+
+	// Outliers are the values drawn as outside
+	// points.
+	Outliers []float64
+
+	// Mean is the mean value, which is drawn
+	// if the ShowMean field of the BoxPlot is set.
+	Mean float64
+
+	// N is the number of values summarized. It
+	// is used for the width of notches and for
+	// ScaleBoxWidths.
+	N int
+}
+
 // BoxPlot implements the Plotter interface, drawing
 // a boxplot to represent the distribution of values.
 type BoxPlot struct {
@@ -77,6 +177,21 @@ type BoxPlot struct {
 	// Horizontal dictates whether the BoxPlot should be in the vertical
 	// (default) or horizontal direction.
 	Horizontal bool
+
+	// Notch specifies that the sides of the box are
+	// notched about the median to show the approximate
+	// 95% confidence interval of the median, given by
+	// the median ± 1.57·IQR/√N. The notches of boxes
+	// whose medians differ significantly do not overlap.
+	// Notches are not drawn if N is zero.
+	Notch bool
+
+	// ShowMean specifies that the mean is drawn
+	// with MeanStyle.
+	ShowMean bool
+
+	// MeanStyle is the style of the mean glyph.
+	MeanStyle draw.GlyphStyle
 }
 
 // NewBoxPlot returns a new BoxPlot that represents
@@ -94,16 +209,88 @@ type BoxPlot struct {
 // whiskers stretch) are the minimum and maximum
 // values that are not outside the fences.
 func NewBoxPlot(w vg.Length, loc float64, values Valuer) (*BoxPlot, error) {
+	return NewBoxPlotWhiskers(w, loc, values, IQRWhiskers(1.5))
+}
+
+// NewBoxPlotWhiskers returns a new BoxPlot, as in NewBoxPlot,
+// with the whiskers drawn according to the rule r. Values beyond
+// the whiskers are drawn as Outside points.
+//
+// An error is returned if r is an IQRWhiskers with a negative
+// multiple, or a PercentileWhiskers whose Low and High are not
+// in increasing order within [0, 100].
+func NewBoxPlotWhiskers(w vg.Length, loc float64, values Valuer, r WhiskerRule) (*BoxPlot, error) {
 	if w < 0 {
 		return nil, errors.New("Negative boxplot width")
 	}
+	if err := checkWhiskers(r); err != nil {
+		return nil, err
+	}
 
 	b := new(BoxPlot)
 	var err error
-	if b.fiveStatPlot, err = newFiveStat(w, loc, values); err != nil {
+	if b.fiveStatPlot, err = newFiveStat(loc, values, r); err != nil {
 		return nil, err
 	}
+	b.setStyle(w)
+
+	if len(b.Values) == 0 {
+		b.Width = 0
+		b.GlyphStyle.Radius = 0
+		b.BoxStyle.Width = 0
+		b.MedianStyle.Width = 0
+		b.WhiskerStyle.Width = 0
+	}
 
+	return b, nil
+}
+
+// NewBoxPlotStats returns a new BoxPlot that draws the
+// precomputed summary statistics s, for distributions
+// whose values are not available. The Values of the
+// returned BoxPlot are the outliers of s.
+//
+// An error is returned if any of the statistics are not
+// finite, if they are not in increasing order from
+// AdjLow through the quartiles and median to AdjHigh,
+// or if N is negative.
+func NewBoxPlotStats(w vg.Length, loc float64, s BoxPlotStats) (*BoxPlot, error) {
+	if w < 0 {
+		return nil, errors.New("Negative boxplot width")
+	}
+	if s.N < 0 {
+		return nil, errors.New("plotter: negative box plot sample size")
+	}
+	if err := CheckFinite(loc, s.Median, s.Quartile1, s.Quartile3, s.AdjLow, s.AdjHigh, s.Mean); err != nil {
+		return nil, err
+	}
+	if err := CheckFinite(s.Outliers...); err != nil {
+		return nil, err
+	}
+	if !(s.AdjLow <= s.Quartile1 && s.Quartile1 <= s.Median && s.Median <= s.Quartile3 && s.Quartile3 <= s.AdjHigh) {
+		return nil, errors.New("plotter: box plot statistics out of order")
+	}
+
+	b := new(BoxPlot)
+	b.Location = loc
+	b.Median = s.Median
+	b.Quartile1, b.Quartile3 = s.Quartile1, s.Quartile3
+	b.AdjLow, b.AdjHigh = s.AdjLow, s.AdjHigh
+	b.Mean = s.Mean
+	b.N = s.N
+	b.Values = append(Values(nil), s.Outliers...)
+	b.Min, b.Max = s.AdjLow, s.AdjHigh
+	for i, v := range b.Values {
+		b.Outside = append(b.Outside, i)
+		b.Min = math.Min(b.Min, v)
+		b.Max = math.Max(b.Max, v)
+	}
+	b.setStyle(w)
+	return b, nil
+}
+
+// setStyle sets the width and default styles of the box plot.
+func (b *BoxPlot) setStyle(w vg.Length) {
 	b.Width = w
 	b.CapWidth = 3 * w / 4
 
@@ -114,19 +301,49 @@ func NewBoxPlot(w vg.Length, loc float64, values Valuer) (*BoxPlot, error) {
 		Width:  vg.Points(0.5),
 		Dashes: []vg.Length{vg.Points(4), vg.Points(2)},
 	}
+	b.MeanStyle = draw.GlyphStyle{
+		Color:  DefaultGlyphStyle.Color,
+		Radius: DefaultGlyphStyle.Radius,
+		Shape:  draw.PlusGlyph{},
+	}
+}
 
-	if len(b.Values) == 0 {
-		b.Width = 0
-		b.GlyphStyle.Radius = 0
-		b.BoxStyle.Width = 0
-		b.MedianStyle.Width = 0
-		b.WhiskerStyle.Width = 0
+// ScaleBoxWidths sets the widths of the boxes in proportion to
+// the square root of the number of values that they summarize,
+// with the box of the most values having the width w. The widths
+// of the whisker caps are scaled with the boxes.
+func ScaleBoxWidths(w vg.Length, boxes ...*BoxPlot) {
+	var max int
+	for _, b := range boxes {
+		if b.N > max {
+			max = b.N
+		}
+	}
+	if max == 0 {
+		return
+	}
+	for _, b := range boxes {
+		f := math.Sqrt(float64(b.N) / float64(max))
+		if b.Width > 0 {
+			b.CapWidth *= vg.Length(f) * w / b.Width
+		} else {
+			b.CapWidth = vg.Length(f) * 3 * w / 4
+		}
+		b.Width = vg.Length(f) * w
 	}
+}
 
-	return b, nil
+// notch returns the ends of the notch about the median, and
+// whether the notch is drawn.
+func (b *BoxPlot) notch() (low, high float64, ok bool) {
+	if !b.Notch || b.N == 0 {
+		return b.Median, b.Median, false
+	}
+	d := 1.57 * (b.Quartile3 - b.Quartile1) / math.Sqrt(float64(b.N))
+	return b.Median - d, b.Median + d, true
 }
 
-func newFiveStat(w vg.Length, loc float64, values Valuer) (fiveStatPlot, error) {
+func newFiveStat(loc float64, values Valuer, r WhiskerRule) (fiveStatPlot, error) {
 	var b fiveStatPlot
 	b.Location = loc
 
@@ -150,21 +367,16 @@ func newFiveStat(w vg.Length, loc float64, values Valuer) (fiveStatPlot, error)
 	}
 	b.Min = sorted[0]
 	b.Max = sorted[len(sorted)-1]
+	b.N = len(sorted)
+	for _, v := range sorted {
+		b.Mean += v
+	}
+	b.Mean /= float64(len(sorted))
 
-	low := b.Quartile1 - 1.5*(b.Quartile3-b.Quartile1)
-	high := b.Quartile3 + 1.5*(b.Quartile3-b.Quartile1)
-	b.AdjLow = math.Inf(1)
-	b.AdjHigh = math.Inf(-1)
+	b.AdjLow, b.AdjHigh = r.Whiskers(sorted, b.Quartile1, b.Quartile3)
 	for i, v := range b.Values {
-		if v > high || v < low {
+		if v > b.AdjHigh || v < b.AdjLow {
 			b.Outside = append(b.Outside, i)
-			continue
-		}
-		if v < b.AdjLow {
-			b.AdjLow = v
-		}
-		if v > b.AdjHigh {
-			b.AdjHigh = v
 		}
 	}
 
@@ -187,51 +399,89 @@ func median(vs Values) float64 {
 
 // Plot draws the BoxPlot on Canvas c and Plot plt.
 func (b *BoxPlot) Plot(c draw.Canvas, plt *plot.Plot) {
-	if b.Horizontal {
-		b := &horizBoxPlot{b}
-		b.Plot(c, plt)
-		return
-	}
+	b.plot(c, plt, b.Horizontal)
+}
 
+// plot draws the box plot with the values along the Y axis, or
+// along the X axis if horizontal is true.
+func (b *BoxPlot) plot(c draw.Canvas, plt *plot.Plot, horizontal bool) {
 	trX, trY := plt.Transforms(&c)
-	x := trX(b.Location)
-	if !c.ContainsX(x) {
+	trLoc, trVal := trX, trY
+	containsLoc, containsVal := c.ContainsX, c.ContainsY
+	clip := c.ClipLinesY
+	// pt returns the point at the position across
+	// the box a and the position along the values v.
+	pt := func(a, v vg.Length) vg.Point { return vg.Point{X: a, Y: v} }
+	if horizontal {
+		trLoc, trVal = trY, trX
+		containsLoc, containsVal = c.ContainsY, c.ContainsX
+		clip = c.ClipLinesX
+		pt = func(a, v vg.Length) vg.Point { return vg.Point{X: v, Y: a} }
+	}
+
+	x := trLoc(b.Location)
+	if !containsLoc(x) {
 		return
 	}
 	x += b.Offset
 
-	med := trY(b.Median)
-	q1 := trY(b.Quartile1)
-	q3 := trY(b.Quartile3)
-	aLow := trY(b.AdjLow)
-	aHigh := trY(b.AdjHigh)
-
-	box := c.ClipLinesY([]vg.Point{
-		{x - b.Width/2, q1},
-		{x - b.Width/2, q3},
-		{x + b.Width/2, q3},
-		{x + b.Width/2, q1},
-		{x - b.Width/2 - b.BoxStyle.Width/2, q1},
-	})
-	c.StrokeLines(b.BoxStyle, box...)
+	med := trVal(b.Median)
+	q1 := trVal(b.Quartile1)
+	q3 := trVal(b.Quartile3)
+	aLow := trVal(b.AdjLow)
+	aHigh := trVal(b.AdjHigh)
+
+	half := b.Width / 2
+	medHalf := half
+	outline := []vg.Point{
+		pt(x-half, q1),
+		pt(x-half, q3),
+		pt(x+half, q3),
+		pt(x+half, q1),
+		pt(x-half-b.BoxStyle.Width/2, q1),
+	}
+	if low, high, ok := b.notch(); ok {
+		nLow, nHigh := trVal(low), trVal(high)
+		medHalf = half / 2
+		outline = []vg.Point{
+			pt(x-half, q1),
+			pt(x-half, nLow),
+			pt(x-medHalf, med),
+			pt(x-half, nHigh),
+			pt(x-half, q3),
+			pt(x+half, q3),
+			pt(x+half, nHigh),
+			pt(x+medHalf, med),
+			pt(x+half, nLow),
+			pt(x+half, q1),
+			pt(x-half-b.BoxStyle.Width/2, q1),
+		}
+	}
+	c.StrokeLines(b.BoxStyle, clip(outline)...)
 
-	medLine := c.ClipLinesY([]vg.Point{
-		{x - b.Width/2, med},
-		{x + b.Width/2, med},
+	medLine := clip([]vg.Point{
+		pt(x-medHalf, med),
+		pt(x+medHalf, med),
 	})
 	c.StrokeLines(b.MedianStyle, medLine...)
 
 	cap := b.CapWidth / 2
-	whisks := c.ClipLinesY([]vg.Point{{x, q3}, {x, aHigh}},
-		[]vg.Point{{x - cap, aHigh}, {x + cap, aHigh}},
-		[]vg.Point{{x, q1}, {x, aLow}},
-		[]vg.Point{{x - cap, aLow}, {x + cap, aLow}})
+	whisks := clip([]vg.Point{pt(x, q3), pt(x, aHigh)},
+		[]vg.Point{pt(x-cap, aHigh), pt(x+cap, aHigh)},
+		[]vg.Point{pt(x, q1), pt(x, aLow)},
+		[]vg.Point{pt(x-cap, aLow), pt(x+cap, aLow)})
 	c.StrokeLines(b.WhiskerStyle, whisks...)
 
 	for _, out := range b.Outside {
-		y := trY(b.Value(out))
-		if c.ContainsY(y) {
-			c.DrawGlyphNoClip(b.GlyphStyle, vg.Point{X: x, Y: y})
+		v := trVal(b.Value(out))
+		if containsVal(v) {
+			c.DrawGlyphNoClip(b.GlyphStyle, pt(x, v))
+		}
+	}
+
+	if b.ShowMean {
+		if v := trVal(b.Mean); containsVal(v) {
+			c.DrawGlyphNoClip(b.MeanStyle, pt(x, v))
 		}
 	}
 }
@@ -244,7 +494,33 @@ func (b *BoxPlot) DataRange() (float64, float64, float64, float64) {
 		b := &horizBoxPlot{b}
 		return b.DataRange()
 	}
-	return b.Location, b.Location, b.Min, b.Max
+	min, max := b.valueRange()
+	return b.Location, b.Location, min, max
+}
+
+// valueRange returns the range of the values drawn by
+// the box plot, including the notch and the mean.
+func (b *BoxPlot) valueRange() (min, max float64) {
+	min, max = b.Min, b.Max
+	if low, high, ok := b.notch(); ok {
+		min, max = math.Min(min, low), math.Max(max, high)
+	}
+	if b.ShowMean {
+		min, max = math.Min(min, b.Mean), math.Max(max, b.Mean)
+	}
+	return min, max
+}
+
+// meanGlyphBox returns the GlyphBox of the mean glyph
+// at the normalized location x, y.
+func (b *BoxPlot) meanGlyphBox(x, y float64) plot.GlyphBox {
+	off := vg.Point{X: b.Offset}
+	if b.Horizontal {
+		off = vg.Point{Y: b.Offset}
+	}
+	r := b.MeanStyle.Rectangle()
+	r.Min, r.Max = r.Min.Add(off), r.Max.Add(off)
+	return plot.GlyphBox{X: x, Y: y, Rectangle: r}
 }
 
 // GlyphBoxes returns a slice of GlyphBoxes for the
@@ -268,6 +544,9 @@ func (b *BoxPlot) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 		Min: vg.Point{X: b.Offset - (b.Width/2 + b.BoxStyle.Width/2)},
 		Max: vg.Point{X: b.Offset + (b.Width/2 + b.BoxStyle.Width/2)},
 	}
+	if b.ShowMean {
+		bs = append(bs, b.meanGlyphBox(plt.X.Norm(b.Location), plt.Y.Norm(b.Mean)))
+	}
 	return bs
 }
 
@@ -314,59 +593,20 @@ func (o boxPlotOutsideLabels) Label(i int) string {
 
 // horizBoxPlot is like a regular BoxPlot, however,
 // it draws horizontally instead of Vertically.
-// TODO: Merge code for horizontal and vertical box plots as has been done for
-// bar charts.
+// TODO: Merge the remaining code for horizontal and vertical box plots as
+// has been done for bar charts.
 type horizBoxPlot struct{ *BoxPlot }
 
 func (b horizBoxPlot) Plot(c draw.Canvas, plt *plot.Plot) {
-	trX, trY := plt.Transforms(&c)
-	y := trY(b.Location)
-	if !c.ContainsY(y) {
-		return
-	}
-	y += b.Offset
-
-	med := trX(b.Median)
-	q1 := trX(b.Quartile1)
-	q3 := trX(b.Quartile3)
-	aLow := trX(b.AdjLow)
-	aHigh := trX(b.AdjHigh)
-
-	box := c.ClipLinesX([]vg.Point{
-		{q1, y - b.Width/2},
-		{q3, y - b.Width/2},
-		{q3, y + b.Width/2},
-		{q1, y + b.Width/2},
-		{q1, y - b.Width/2 - b.BoxStyle.Width/2},
-	})
-	c.StrokeLines(b.BoxStyle, box...)
-
-	medLine := c.ClipLinesX([]vg.Point{
-		{med, y - b.Width/2},
-		{med, y + b.Width/2},
-	})
-	c.StrokeLines(b.MedianStyle, medLine...)
-
-	cap := b.CapWidth / 2
-	whisks := c.ClipLinesX([]vg.Point{{q3, y}, {aHigh, y}},
-		[]vg.Point{{aHigh, y - cap}, {aHigh, y + cap}},
-		[]vg.Point{{q1, y}, {aLow, y}},
-		[]vg.Point{{aLow, y - cap}, {aLow, y + cap}})
-	c.StrokeLines(b.WhiskerStyle, whisks...)
-
-	for _, out := range b.Outside {
-		x := trX(b.Value(out))
-		if c.ContainsX(x) {
-			c.DrawGlyphNoClip(b.GlyphStyle, vg.Point{X: x, Y: y})
-		}
-	}
+	b.plot(c, plt, true)
 }
 
 // DataRange returns the minimum and maximum x
 // and y values, implementing the plot.DataRanger
 // interface.
 func (b horizBoxPlot) DataRange() (float64, float64, float64, float64) {
-	return b.Min, b.Max, b.Location, b.Location
+	min, max := b.valueRange()
+	return min, max, b.Location, b.Location
 }
 
 // GlyphBoxes returns a slice of GlyphBoxes for the
@@ -385,6 +625,9 @@ func (b horizBoxPlot) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 		Min: vg.Point{Y: b.Offset - (b.Width/2 + b.BoxStyle.Width/2)},
 		Max: vg.Point{Y: b.Offset + (b.Width/2 + b.BoxStyle.Width/2)},
 	}
+	if b.ShowMean {
+		bs = append(bs, b.meanGlyphBox(plt.X.Norm(b.Mean), plt.Y.Norm(b.Location)))
+	}
 	return bs
 }
 
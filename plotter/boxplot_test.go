@@ -7,6 +7,7 @@ package plotter_test
 import (
 	"fmt"
 	"log"
+	"math"
 	"testing"
 
 	"golang.org/x/exp/rand"
@@ -159,4 +160,152 @@ func ExampleBoxPlot() {
 func TestBoxPlot(t *testing.T) {
 	cmpimg.CheckPlot(ExampleBoxPlot, t, "verticalBoxPlot.png",
 		"horizontalBoxPlot.png", "groupedBoxPlot.png")
+	cmpimg.CheckPlot(ExampleBoxPlot_notched, t, "notchedBoxPlot.png")
+}
+
+// ExampleBoxPlot_notched draws notched box plots with mean markers and
+// widths proportional to the square root of the sample size, and a box
+// plot drawn from precomputed statistics.
+func ExampleBoxPlot_notched() {
+	rnd := rand.New(rand.NewSource(1))
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Notched box plots"
+
+	var boxes []*plotter.BoxPlot
+	for i, n := range []int{20, 80, 320} {
+		vs := make(plotter.Values, n)
+		for j := range vs {
+			vs[j] = float64(i)/2 + rnd.ExpFloat64()
+		}
+		b, err := plotter.NewBoxPlotWhiskers(vg.Points(20), float64(i), vs, plotter.PercentileWhiskers{Low: 5, High: 95})
+		if err != nil {
+			log.Panic(err)
+		}
+		boxes = append(boxes, b)
+	}
+
+	// The statistics of the last box are computed elsewhere,
+	// for example by a distributed job.
+	b, err := plotter.NewBoxPlotStats(vg.Points(20), 3, plotter.BoxPlotStats{
+		Median: 2, Quartile1: 1.4, Quartile3: 2.9,
+		AdjLow: 0.2, AdjHigh: 5,
+		Outliers: []float64{6.1, 7.4},
+		Mean:     2.3,
+		N:        1000,
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+	boxes = append(boxes, b)
+
+	plotter.ScaleBoxWidths(vg.Points(30), boxes...)
+	for _, b := range boxes {
+		b.Notch = true
+		b.ShowMean = true
+		p.Add(b)
+	}
+	p.NominalX("n=20", "n=80", "n=320", "summary")
+
+	err = p.Save(300, 200, "testdata/notchedBoxPlot.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestBoxPlotWhiskers(t *testing.T) {
+	vs := plotter.Values{-10, 1, 2, 3, 4, 5, 6, 7, 8, 9, 30}
+	for _, test := range []struct {
+		rule        plotter.WhiskerRule
+		low, high   float64
+		wantOutside int
+	}{
+		{rule: plotter.IQRWhiskers(1.5), low: 1, high: 9, wantOutside: 2},
+		{rule: plotter.MinMaxWhiskers{}, low: -10, high: 30, wantOutside: 0},
+		{rule: plotter.PercentileWhiskers{Low: 10, High: 90}, low: 1, high: 9, wantOutside: 2},
+	} {
+		b, err := plotter.NewBoxPlotWhiskers(vg.Points(10), 0, vs, test.rule)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if b.AdjLow != test.low || b.AdjHigh != test.high {
+			t.Errorf("unexpected whiskers for %T: got:[%v, %v] want:[%v, %v]", test.rule, b.AdjLow, b.AdjHigh, test.low, test.high)
+		}
+		if len(b.Outside) != test.wantOutside {
+			t.Errorf("unexpected number of outside points for %T: got:%d want:%d", test.rule, len(b.Outside), test.wantOutside)
+		}
+	}
+
+	for _, rule := range []plotter.WhiskerRule{
+		plotter.IQRWhiskers(-1),
+		plotter.IQRWhiskers(math.NaN()),
+		plotter.PercentileWhiskers{Low: -5, High: 95},
+		plotter.PercentileWhiskers{Low: 5, High: 105},
+		plotter.PercentileWhiskers{Low: 90, High: 10},
+		plotter.PercentileWhiskers{Low: math.NaN(), High: 95},
+	} {
+		if _, err := plotter.NewBoxPlotWhiskers(vg.Points(10), 0, vs, rule); err == nil {
+			t.Errorf("expected error for whisker rule %#v", rule)
+		}
+	}
+}
+
+func TestBoxPlotStats(t *testing.T) {
+	s := plotter.BoxPlotStats{
+		Median: 5, Quartile1: 4, Quartile3: 6,
+		AdjLow: 2, AdjHigh: 8,
+		Outliers: []float64{-1, 12},
+		Mean:     5.5,
+		N:        16,
+	}
+	b, err := plotter.NewBoxPlotStats(vg.Points(10), 1, s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	xmin, xmax, ymin, ymax := b.DataRange()
+	if xmin != 1 || xmax != 1 || ymin != -1 || ymax != 12 {
+		t.Errorf("unexpected data range: got:%v %v %v %v want:1 1 -1 12", xmin, xmax, ymin, ymax)
+	}
+
+	// The notch of a small sample extends beyond
+	// the whiskers and is included in the range.
+	s.N = 1
+	s.Outliers = nil
+	b, err = plotter.NewBoxPlotStats(vg.Points(10), 1, s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b.Notch = true
+	_, _, ymin, ymax = b.DataRange()
+	if math.Abs(ymin-1.86) > 1e-12 || math.Abs(ymax-8.14) > 1e-12 {
+		t.Errorf("unexpected notched data range: got:%v %v want:1.86 8.14", ymin, ymax)
+	}
+
+	s.Median = 7
+	s.Quartile3 = 6.5
+	if _, err := plotter.NewBoxPlotStats(vg.Points(10), 1, s); err == nil {
+		t.Error("expected error for statistics out of order")
+	}
+	if _, err := plotter.NewBoxPlotStats(vg.Points(10), 1, plotter.BoxPlotStats{N: -1}); err == nil {
+		t.Error("expected error for negative sample size")
+	}
+
+	small, err := plotter.NewBoxPlotStats(vg.Points(10), 0, plotter.BoxPlotStats{N: 25})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	large, err := plotter.NewBoxPlotStats(vg.Points(10), 1, plotter.BoxPlotStats{N: 100})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	plotter.ScaleBoxWidths(vg.Points(40), small, large)
+	if small.Width != vg.Points(20) || large.Width != vg.Points(40) {
+		t.Errorf("unexpected scaled widths: got:%v %v want:%v %v", small.Width, large.Width, vg.Points(20), vg.Points(40))
+	}
+	if small.CapWidth != vg.Points(15) {
+		t.Errorf("unexpected scaled cap width: got:%v want:%v", small.CapWidth, vg.Points(15))
+	}
 }
@@ -0,0 +1,358 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/palette"
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/draw"
+)
+
+// Bubbles implements the Plotter interface, drawing a circle at each
+// point of a set of XYZ data with an area that represents its Z value.
+type Bubbles struct {
+	// XYZs is a copy of the points for this plotter.
+	XYZs
+
+	// MinZ and MaxZ are the Z values drawn with
+	// bubbles of radius MinRadius and MaxRadius.
+	// The area of the bubbles varies linearly with
+	// Z between them, so when MinZ and MinRadius
+	// are both zero the areas are proportional to
+	// Z. Z values outside the range are clamped
+	// to it.
+	MinZ, MaxZ float64
+
+	// MinRadius and MaxRadius are the radii of
+	// the bubbles at MinZ and MaxZ.
+	MinRadius, MaxRadius vg.Length
+
+	// ColorMap is used to fill the bubbles by their Z
+	// value. Z values outside the range of the ColorMap
+	// are clamped to it. If ColorMap is nil, bubbles
+	// are filled with Color.
+	ColorMap palette.ColorMap
+
+	// Color is the fill color of the bubbles when
+	// ColorMap is nil. If Color is also nil, the
+	// bubbles are not filled.
+	Color color.Color
+
+	// LineStyle is the style of the outline of the
+	// bubbles.
+	LineStyle draw.LineStyle
+}
+
+// NewBubbles returns a Bubbles plotter for the given data with
+// bubbles of radius between 1 and 20 points. MinZ is zero if all
+// of the Z values are non-negative, so that the bubble areas are
+// nearly proportional to Z, and the minimum Z value otherwise.
+// MaxZ is the maximum Z value. An error is returned if any of the
+// values are not finite.
+func NewBubbles(xyzs XYZer) (*Bubbles, error) {
+	data, err := CopyXYZs(xyzs)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrNoData
+	}
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, p := range data {
+		if err := CheckFinite(p.X, p.Y, p.Z); err != nil {
+			return nil, err
+		}
+		min = math.Min(min, p.Z)
+		max = math.Max(max, p.Z)
+	}
+	if min > 0 {
+		min = 0
+	}
+	return &Bubbles{
+		XYZs:      data,
+		MinZ:      min,
+		MaxZ:      max,
+		MinRadius: vg.Points(1),
+		MaxRadius: vg.Points(20),
+		Color:     color.RGBA{R: 196, B: 128, A: 255},
+		LineStyle: DefaultLineStyle,
+	}, nil
+}
+
+// Radius returns the radius of a bubble with the value z.
+func (b *Bubbles) Radius(z float64) vg.Length {
+	if b.MaxZ <= b.MinZ {
+		return b.MaxRadius
+	}
+	f := (math.Max(b.MinZ, math.Min(b.MaxZ, z)) - b.MinZ) / (b.MaxZ - b.MinZ)
+	rmin, rmax := float64(b.MinRadius), float64(b.MaxRadius)
+	return vg.Length(math.Sqrt(rmin*rmin + f*(rmax*rmax-rmin*rmin)))
+}
+
+// color returns the fill color of a bubble with the value z.
+func (b *Bubbles) color(z float64) color.Color {
+	if b.ColorMap == nil {
+		return b.Color
+	}
+	z = math.Max(b.ColorMap.Min(), math.Min(b.ColorMap.Max(), z))
+	col, err := b.ColorMap.At(z)
+	if err != nil {
+		panic(err)
+	}
+	return col
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+// Larger bubbles are drawn first so that they do not hide smaller
+// bubbles.
+func (b *Bubbles) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	order := make([]int, len(b.XYZs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return b.XYZs[order[i]].Z > b.XYZs[order[j]].Z
+	})
+	for _, i := range order {
+		p := b.XYZs[i]
+		drawBubble(c, vg.Point{X: trX(p.X), Y: trY(p.Y)}, b.Radius(p.Z), b.color(p.Z), b.LineStyle)
+	}
+}
+
+// drawBubble draws a circle of radius r centered at pt, filled with
+// col if it is not nil and outlined with sty if its width is not zero.
+func drawBubble(c draw.Canvas, pt vg.Point, r vg.Length, col color.Color, sty draw.LineStyle) {
+	if !c.Contains(pt) {
+		return
+	}
+	var p vg.Path
+	p.Move(vg.Point{X: pt.X + r, Y: pt.Y})
+	p.Arc(pt, r, 0, 2*math.Pi)
+	p.Close()
+	if col != nil {
+		c.SetColor(col)
+		c.Fill(p)
+	}
+	if sty.Width != 0 {
+		c.SetLineStyle(sty)
+		c.Stroke(p)
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (b *Bubbles) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return XYRange(XYValues{b.XYZs})
+}
+
+// GlyphBoxes implements the GlyphBoxes method
+// of the plot.GlyphBoxer interface.
+func (b *Bubbles) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := make([]plot.GlyphBox, len(b.XYZs))
+	for i, p := range b.XYZs {
+		r := b.Radius(p.Z)
+		bs[i].X = plt.X.Norm(p.X)
+		bs[i].Y = plt.Y.Norm(p.Y)
+		bs[i].Rectangle = vg.Rectangle{
+			Min: vg.Point{X: -r, Y: -r},
+			Max: vg.Point{X: +r, Y: +r},
+		}
+	}
+	return bs
+}
+
+// Thumbnail implements the Thumbnail method
+// of the plot.Thumbnailer interface.
+func (b *Bubbles) Thumbnail(c *draw.Canvas) {
+	r := (c.Max.Y - c.Min.Y) / 2
+	col := b.Color
+	if b.ColorMap != nil {
+		col = b.color((b.ColorMap.Min() + b.ColorMap.Max()) / 2)
+	}
+	drawBubble(*c, c.Center(), r, col, b.LineStyle)
+}
+
+// BubbleLegend implements the Plotter interface, drawing a block in a
+// corner of the data area that shows bubbles of reference sizes of a
+// Bubbles plotter labeled with their values.
+type BubbleLegend struct {
+	// Bubbles is the plotter whose sizes
+	// are explained by the legend.
+	Bubbles *Bubbles
+
+	// Values are the Z values of the
+	// reference bubbles.
+	Values []float64
+
+	// Labels are the labels of the reference bubbles.
+	// If Labels is nil the values are formatted
+	// using the shortest representation.
+	Labels []string
+
+	// TextStyle is the style of the labels.
+	TextStyle draw.TextStyle
+
+	// LineStyle is the style of the outlines of
+	// the reference bubbles.
+	LineStyle draw.LineStyle
+
+	// Color is the fill color of the reference
+	// bubbles. If Color is nil the bubbles are not
+	// filled.
+	Color color.Color
+
+	// Background is the fill color of the block. If
+	// Background is nil the block is not filled.
+	Background color.Color
+
+	// Padding is the space around the block and
+	// between its bubbles and labels.
+	Padding vg.Length
+
+	// Top and Left specify the corner of the data
+	// area in which the block is drawn.
+	Top, Left bool
+}
+
+// NewBubbleLegend returns a BubbleLegend for b drawn in the top
+// right corner of the data area. If no values are given, up to three
+// evenly spaced round values within the Z range of b are used.
+func NewBubbleLegend(b *Bubbles, values ...float64) (*BubbleLegend, error) {
+	if b == nil {
+		return nil, errors.New("plotter: nil Bubbles in BubbleLegend")
+	}
+	var labels []string
+	if len(values) == 0 {
+		values, labels = bubbleLegendValues(b.MinZ, b.MaxZ, 3)
+	}
+	if err := CheckFinite(values...); err != nil {
+		return nil, err
+	}
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	return &BubbleLegend{
+		Bubbles:   b,
+		Values:    values,
+		Labels:    labels,
+		TextStyle: draw.TextStyle{Font: fnt, YAlign: draw.YCenter},
+		LineStyle: DefaultLineStyle,
+		Padding:   vg.Points(4),
+		Top:       true,
+	}, nil
+}
+
+// bubbleLegendValues returns up to n of the labeled default
+// ticks in (min, max], with their labels.
+func bubbleLegendValues(min, max float64, n int) ([]float64, []string) {
+	if max <= min {
+		return []float64{max}, []string{strconv.FormatFloat(max, 'g', -1, 64)}
+	}
+	var vs []float64
+	var labels []string
+	for _, t := range (plot.DefaultTicks{}).Ticks(min, max) {
+		if t.Label == "" || t.Value <= min || t.Value > max {
+			continue
+		}
+		vs = append(vs, t.Value)
+		labels = append(labels, t.Label)
+	}
+	if len(vs) == 0 {
+		return []float64{max}, []string{strconv.FormatFloat(max, 'g', -1, 64)}
+	}
+	for len(vs) > n {
+		// Thin the values evenly, keeping the largest.
+		var keepV []float64
+		var keepL []string
+		for i := len(vs) - 1; i >= 0; i -= 2 {
+			keepV = append([]float64{vs[i]}, keepV...)
+			keepL = append([]string{labels[i]}, keepL...)
+		}
+		vs, labels = keepV, keepL
+	}
+	return vs, labels
+}
+
+// label returns the label of the ith value.
+func (l *BubbleLegend) label(i int) string {
+	if i < len(l.Labels) {
+		return l.Labels[i]
+	}
+	return strconv.FormatFloat(l.Values[i], 'g', -1, 64)
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+// The reference bubbles are drawn in a column in increasing order
+// of size from the top, each with its label to the right.
+func (l *BubbleLegend) Plot(c draw.Canvas, plt *plot.Plot) {
+	if len(l.Values) == 0 {
+		return
+	}
+	var maxR, textW, height vg.Length
+	for i, v := range l.Values {
+		r := l.Bubbles.Radius(v)
+		if r > maxR {
+			maxR = r
+		}
+		if w := l.TextStyle.Width(l.label(i)); w > textW {
+			textW = w
+		}
+		height += l.rowHeight(v, i)
+	}
+	height += vg.Length(len(l.Values)+1) * l.Padding
+	width := 2*maxR + textW + 3*l.Padding
+
+	var box vg.Rectangle
+	box.Min.X = c.Max.X - l.Padding - width
+	if l.Left {
+		box.Min.X = c.Min.X + l.Padding
+	}
+	box.Min.Y = c.Min.Y + l.Padding
+	if l.Top {
+		box.Min.Y = c.Max.Y - l.Padding - height
+	}
+	box.Max = box.Min.Add(vg.Point{X: width, Y: height})
+
+	if l.Background != nil {
+		c.SetColor(l.Background)
+		c.Fill(box.Path())
+	}
+	cx := box.Min.X + l.Padding + maxR
+	y := box.Max.Y - l.Padding
+	sty := l.TextStyle
+	sty.XAlign = draw.XLeft
+	for i, v := range l.Values {
+		h := l.rowHeight(v, i)
+		mid := y - h/2
+		drawBubble(c, vg.Point{X: cx, Y: mid}, l.Bubbles.Radius(v), l.Color, l.LineStyle)
+		c.FillText(sty, vg.Point{X: cx + maxR + l.Padding, Y: mid}, l.label(i))
+		y -= h + l.Padding
+	}
+}
+
+// rowHeight returns the height of the row of the ith value v.
+func (l *BubbleLegend) rowHeight(v float64, i int) vg.Length {
+	h := 2 * l.Bubbles.Radius(v)
+	if th := l.TextStyle.Height(l.label(i)); th > h {
+		h = th
+	}
+	return h
+}
+
+// DataRange implements the DataRange method of the plot.DataRanger
+// interface. The returned range is empty so that the legend does not
+// affect the axes.
+func (l *BubbleLegend) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1)
+}
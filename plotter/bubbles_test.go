@@ -14,6 +14,7 @@ import (
 
 	"github.com/gshk/plot"
 	"github.com/gshk/plot/cmpimg"
+	"github.com/gshk/plot/palette/moreland"
 	"github.com/gshk/plot/plotter"
 	"github.com/gshk/plot/vg"
 	"github.com/gshk/plot/vg/draw"
@@ -88,3 +89,91 @@ func ExampleScatter_bubbles() {
 func TestNewBubbles(t *testing.T) {
 	cmpimg.CheckPlot(ExampleScatter_bubbles, t, "bubbles.png")
 }
+
+// ExampleBubbles draws the populations of some cities as
+// bubbles with areas proportional to the populations, colored
+// by population density, with a legend explaining the sizes.
+func ExampleBubbles() {
+	cities := plotter.XYZs{
+		{X: 1, Y: 2, Z: 8.4},
+		{X: 2, Y: 5, Z: 3.9},
+		{X: 4, Y: 3, Z: 2.7},
+		{X: 5, Y: 7, Z: 2.3},
+		{X: 6, Y: 1, Z: 1.6},
+		{X: 7, Y: 4, Z: 0.7},
+		{X: 8, Y: 6, Z: 0.3},
+	}
+
+	b, err := plotter.NewBubbles(cities)
+	if err != nil {
+		log.Panic(err)
+	}
+	b.MinRadius = 0
+	cmap := moreland.SmoothBlueRed()
+	cmap.SetMin(0)
+	cmap.SetMax(10)
+	b.ColorMap = cmap
+
+	l, err := plotter.NewBubbleLegend(b, 1, 4, 8)
+	if err != nil {
+		log.Panic(err)
+	}
+	l.Labels = []string{"1M", "4M", "8M"}
+	l.Background = color.White
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "City populations"
+	p.X.Max = 12
+	p.Add(b, l)
+
+	err = p.Save(300, 200, "testdata/bubbles_legend.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestBubblesExample(t *testing.T) {
+	cmpimg.CheckPlot(ExampleBubbles, t, "bubbles_legend.png")
+}
+
+func TestBubbles(t *testing.T) {
+	b, err := plotter.NewBubbles(plotter.XYZs{{X: 0, Y: 0, Z: 1}, {X: 1, Y: 1, Z: 4}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.MinZ != 0 || b.MaxZ != 4 {
+		t.Errorf("unexpected Z range: got:[%v, %v] want:[0, 4]", b.MinZ, b.MaxZ)
+	}
+	b.MinRadius = 0
+	// Areas are proportional to Z, so the radius of
+	// a quarter of the maximum value is half of the
+	// maximum radius.
+	if got, want := b.Radius(1), b.MaxRadius/2; math.Abs(float64(got-want)) > 1e-9 {
+		t.Errorf("unexpected radius: got:%v want:%v", got, want)
+	}
+	if got := b.Radius(10); got != b.MaxRadius {
+		t.Errorf("unexpected radius of clamped value: got:%v want:%v", got, b.MaxRadius)
+	}
+
+	l, err := plotter.NewBubbleLegend(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(l.Values) == 0 || len(l.Values) > 3 || len(l.Labels) != len(l.Values) {
+		t.Fatalf("unexpected default legend values: %v %q", l.Values, l.Labels)
+	}
+	for _, v := range l.Values {
+		if v <= b.MinZ || v > b.MaxZ {
+			t.Errorf("default legend value %v outside (%v, %v]", v, b.MinZ, b.MaxZ)
+		}
+	}
+
+	for _, xyzs := range []plotter.XYZs{nil, {{X: 0, Y: 0, Z: math.NaN()}}, {{X: 0, Y: 0, Z: math.Inf(1)}}} {
+		if _, err := plotter.NewBubbles(xyzs); err == nil {
+			t.Errorf("expected error for %v", xyzs)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/palette"
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/draw"
+)
+
+// DatedValue is a value observed on a day.
+type DatedValue struct {
+	// Date is the day of the value. The time
+	// of day and location are ignored after
+	// the year, month and day are taken.
+	Date time.Time
+
+	Value float64
+}
+
+// Calendar implements the Plotter interface, drawing a calendar heat map
+// of daily values with a cell for each day. The cells are laid out in
+// columns of weeks from left to right with a row for each day of the week
+// from the top. Week column i spans X locations from i to i+1, counting
+// from the week holding Start, and the row of the first day of the week
+// spans Y locations from 6 to 7. The axes can be labeled by the tickers
+// returned by the MonthTicks and WeekdayTicks methods.
+type Calendar struct {
+	// Values are the values of the calendar in order of
+	// date, with at most one value for each day.
+	Values []DatedValue
+
+	// Start and End are the first and last days drawn.
+	Start, End time.Time
+
+	// FirstWeekday is the day of the week in the
+	// top row of the calendar.
+	FirstWeekday time.Weekday
+
+	// ColorMap is used to fill the cells of days with a
+	// value. Values outside the range of the ColorMap are
+	// clamped to it.
+	ColorMap palette.ColorMap
+
+	// Empty is the fill color of the cells of days with no
+	// value or with a NaN value. If Empty is nil those cells
+	// are not filled.
+	Empty color.Color
+
+	// Gap is the space between neighboring cells.
+	Gap vg.Length
+
+	// MonthStyle is the style of the lines separating
+	// the months. If its width is zero the lines are
+	// not drawn.
+	MonthStyle draw.LineStyle
+}
+
+// NewCalendar returns a Calendar of the values from the day of the
+// earliest value to the day of the latest, with weeks starting on
+// Sunday. Values on the same day are summed. The range of cm is set to
+// the range of the values. An error is returned if there are no values
+// or any of the values are infinite.
+func NewCalendar(vs []DatedValue, cm palette.ColorMap) (*Calendar, error) {
+	if len(vs) == 0 {
+		return nil, ErrNoData
+	}
+	if cm == nil {
+		return nil, errors.New("plotter: nil ColorMap in Calendar")
+	}
+	days := make(map[time.Time]float64, len(vs))
+	for _, v := range vs {
+		if math.IsInf(v.Value, 0) {
+			return nil, ErrInfinity
+		}
+		days[civilDay(v.Date)] += v.Value
+	}
+	values := make([]DatedValue, 0, len(days))
+	min, max := math.Inf(1), math.Inf(-1)
+	for d, v := range days {
+		values = append(values, DatedValue{Date: d, Value: v})
+		if !math.IsNaN(v) {
+			min = math.Min(min, v)
+			max = math.Max(max, v)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].Date.Before(values[j].Date) })
+	if min <= max {
+		cm.SetMin(min)
+		cm.SetMax(max)
+	}
+
+	return &Calendar{
+		Values:     values,
+		Start:      values[0].Date,
+		End:        values[len(values)-1].Date,
+		ColorMap:   cm,
+		Empty:      color.Gray{Y: 235},
+		Gap:        vg.Points(1),
+		MonthStyle: DefaultLineStyle,
+	}, nil
+}
+
+// civilDay returns midnight UTC of the day of t in its location.
+func civilDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// cell returns the week column and weekday row of the day d.
+func (cal *Calendar) cell(d time.Time) (week, row int) {
+	start := civilDay(cal.Start)
+	lead := (int(start.Weekday()) - int(cal.FirstWeekday) + 7) % 7
+	days := int(civilDay(d).Sub(start).Hours()/24) + lead
+	return days / 7, days % 7
+}
+
+// cellRect returns the rectangle of data coordinates
+// of the cell at the given week column and row.
+func cellRect(week, row int) (xmin, xmax, ymin, ymax float64) {
+	return float64(week), float64(week + 1), float64(6 - row), float64(7 - row)
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (cal *Calendar) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	values := make(map[time.Time]float64, len(cal.Values))
+	for _, v := range cal.Values {
+		values[civilDay(v.Date)] = v.Value
+	}
+
+	start, end := civilDay(cal.Start), civilDay(cal.End)
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		col := cal.Empty
+		if v, ok := values[d]; ok && !math.IsNaN(v) {
+			col = cal.color(v)
+		}
+		if col == nil {
+			continue
+		}
+		xmin, xmax, ymin, ymax := cellRect(cal.cell(d))
+		pts := []vg.Point{
+			{X: trX(xmin) + cal.Gap/2, Y: trY(ymin) + cal.Gap/2},
+			{X: trX(xmax) - cal.Gap/2, Y: trY(ymin) + cal.Gap/2},
+			{X: trX(xmax) - cal.Gap/2, Y: trY(ymax) - cal.Gap/2},
+			{X: trX(xmin) + cal.Gap/2, Y: trY(ymax) - cal.Gap/2},
+		}
+		c.FillPolygon(col, c.ClipPolygonXY(pts))
+	}
+
+	if cal.MonthStyle.Width == 0 {
+		return
+	}
+	for _, first := range cal.monthStarts() {
+		if !first.After(start) {
+			continue
+		}
+		week, row := cal.cell(first)
+		x0, x1 := float64(week), float64(week+1)
+		y := float64(7 - row)
+		line := []vg.Point{{X: trX(x0), Y: trY(0)}, {X: trX(x0), Y: trY(y)}}
+		if row != 0 {
+			line = append(line,
+				vg.Point{X: trX(x1), Y: trY(y)},
+				vg.Point{X: trX(x1), Y: trY(7)},
+			)
+		} else {
+			line[1].Y = trY(7)
+		}
+		c.StrokeLines(cal.MonthStyle, c.ClipLinesXY(line)...)
+	}
+}
+
+// color returns the fill color of a cell with the value v.
+func (cal *Calendar) color(v float64) color.Color {
+	v = math.Max(cal.ColorMap.Min(), math.Min(cal.ColorMap.Max(), v))
+	col, err := cal.ColorMap.At(v)
+	if err != nil {
+		panic(err)
+	}
+	return col
+}
+
+// monthStarts returns the first days of the months
+// overlapping the calendar.
+func (cal *Calendar) monthStarts() []time.Time {
+	start, end := civilDay(cal.Start), civilDay(cal.End)
+	var firsts []time.Time
+	for d := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC); !d.After(end); d = d.AddDate(0, 1, 0) {
+		firsts = append(firsts, d)
+	}
+	return firsts
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (cal *Calendar) DataRange() (xmin, xmax, ymin, ymax float64) {
+	week, _ := cal.cell(cal.End)
+	return 0, float64(week + 1), 0, 7
+}
+
+// MonthTicks returns a ticker labeling the X axis with the
+// abbreviated names of the months, centered on their weeks.
+func (cal *Calendar) MonthTicks() plot.Ticker {
+	start, end := civilDay(cal.Start), civilDay(cal.End)
+	var ticks plot.ConstantTicks
+	for _, first := range cal.monthStarts() {
+		last := first.AddDate(0, 1, -1)
+		if first.Before(start) {
+			first = start
+		}
+		if last.After(end) {
+			last = end
+		}
+		w0, _ := cal.cell(first)
+		w1, _ := cal.cell(last)
+		ticks = append(ticks, plot.Tick{
+			Value: float64(w0+w1+1) / 2,
+			Label: first.Month().String()[:3],
+		})
+	}
+	return ticks
+}
+
+// WeekdayTicks returns a ticker labeling the Y axis with
+// the abbreviated names of the days of the week.
+func (cal *Calendar) WeekdayTicks() plot.Ticker {
+	ticks := make(plot.ConstantTicks, 7)
+	for row := range ticks {
+		day := time.Weekday((int(cal.FirstWeekday) + row) % 7)
+		ticks[row] = plot.Tick{
+			Value: 6.5 - float64(row),
+			Label: day.String()[:3],
+		}
+	}
+	return ticks
+}
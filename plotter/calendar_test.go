@@ -0,0 +1,130 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter_test
+
+import (
+	"log"
+	"math"
+	"testing"
+	"time"
+
+	"golang.org/x/exp/rand"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/cmpimg"
+	"github.com/gshk/plot/palette/moreland"
+	"github.com/gshk/plot/plotter"
+	"github.com/gshk/plot/vg/draw"
+	"github.com/gshk/plot/vg/recorder"
+)
+
+// ExampleCalendar draws a calendar heat map of the number of
+// commits made each day over six months.
+func ExampleCalendar() {
+	rnd := rand.New(rand.NewSource(1))
+	start := time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC)
+	var vs []plotter.DatedValue
+	for d := 0; d < 181; d++ {
+		day := start.AddDate(0, 0, d)
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			continue
+		}
+		vs = append(vs, plotter.DatedValue{Date: day, Value: float64(rnd.Intn(12))})
+	}
+
+	cal, err := plotter.NewCalendar(vs, moreland.SmoothGreenPurple())
+	if err != nil {
+		log.Panic(err)
+	}
+	cal.FirstWeekday = time.Monday
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Commits"
+	p.Add(cal)
+	p.X.Padding, p.Y.Padding = 0, 0
+	p.X.Width, p.Y.Width = 0, 0
+	p.X.Tick.Length, p.Y.Tick.Length = 0, 0
+	p.X.Tick.Marker = cal.MonthTicks()
+	p.Y.Tick.Marker = cal.WeekdayTicks()
+
+	err = p.Save(400, 120, "testdata/calendar.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestCalendarExample(t *testing.T) {
+	cmpimg.CheckPlot(ExampleCalendar, t, "calendar.png")
+}
+
+func TestCalendar(t *testing.T) {
+	// 2019-01-01 was a Tuesday.
+	loc := time.FixedZone("UTC+10", 10*60*60)
+	vs := []plotter.DatedValue{
+		{Date: time.Date(2019, time.January, 1, 9, 0, 0, 0, loc), Value: 1},
+		{Date: time.Date(2019, time.January, 1, 23, 0, 0, 0, loc), Value: 2},
+		{Date: time.Date(2019, time.February, 3, 0, 0, 0, 0, time.UTC), Value: 5},
+		{Date: time.Date(2019, time.January, 20, 0, 0, 0, 0, time.UTC), Value: math.NaN()},
+	}
+	cm := moreland.SmoothBlueRed()
+	cal, err := plotter.NewCalendar(vs, cm)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cal.Values) != 3 || cal.Values[0].Value != 3 {
+		t.Errorf("values on the same day not summed: got:%v", cal.Values)
+	}
+	if cm.Min() != 3 || cm.Max() != 5 {
+		t.Errorf("unexpected color map range: got:[%v, %v] want:[3, 5]", cm.Min(), cm.Max())
+	}
+
+	// With weeks starting on Sunday, 2019-02-03 is
+	// at the top of the sixth column.
+	xmin, xmax, ymin, ymax := cal.DataRange()
+	if xmin != 0 || xmax != 6 || ymin != 0 || ymax != 7 {
+		t.Errorf("unexpected data range: got:%v %v %v %v want:0 6 0 7", xmin, xmax, ymin, ymax)
+	}
+	cal.FirstWeekday = time.Monday
+	if _, xmax, _, _ = cal.DataRange(); xmax != 5 {
+		t.Errorf("unexpected week columns with Monday first: got:%v want:5", xmax)
+	}
+
+	months := cal.MonthTicks().Ticks(0, 0)
+	if len(months) != 2 || months[0].Label != "Jan" || months[1].Label != "Feb" {
+		t.Errorf("unexpected month ticks: got:%v", months)
+	}
+	days := cal.WeekdayTicks().Ticks(0, 0)
+	if days[0].Label != "Mon" || days[0].Value != 6.5 || days[6].Label != "Sun" {
+		t.Errorf("unexpected weekday ticks: got:%v", days)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.BackgroundColor = nil
+	p.Add(cal)
+	var rec recorder.Canvas
+	p.Draw(draw.NewCanvas(&rec, 300, 100))
+	var cells int
+	for _, a := range rec.Actions {
+		if _, ok := a.(*recorder.Fill); ok {
+			cells++
+		}
+	}
+	if want := 34; cells != want {
+		t.Errorf("unexpected number of cells: got:%d want:%d", cells, want)
+	}
+
+	if _, err := plotter.NewCalendar(nil, cm); err == nil {
+		t.Error("expected error for no values")
+	}
+	if _, err := plotter.NewCalendar([]plotter.DatedValue{{Value: math.Inf(1)}}, cm); err == nil {
+		t.Error("expected error for infinite value")
+	}
+}
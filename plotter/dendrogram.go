@@ -0,0 +1,358 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/draw"
+)
+
+// A Merge is a step of an agglomerative hierarchical clustering,
+// joining two clusters into one.
+//
+// Clusters are identified by integers. For a clustering of n
+// leaves, the identifiers 0 to n-1 refer to the leaves and the
+// identifier n+i refers to the cluster formed by the ith Merge.
+type Merge struct {
+	// A and B are the identifiers of the joined clusters.
+	A, B int
+
+	// Height is the distance between the joined
+	// clusters. It must not be less than the heights
+	// of the merges that formed A and B.
+	Height float64
+}
+
+// DendrogramOrientation specifies the side of a
+// Dendrogram holding the root of the tree.
+type DendrogramOrientation int
+
+const (
+	// DendrogramTop places the leaves along the X axis
+	// with the root above them.
+	DendrogramTop DendrogramOrientation = iota
+
+	// DendrogramBottom places the leaves along the X axis
+	// with the root below them. The heights are negated
+	// along the Y axis.
+	DendrogramBottom
+
+	// DendrogramLeft places the leaves along the Y axis
+	// with the root to their left. The heights are negated
+	// along the X axis.
+	DendrogramLeft
+
+	// DendrogramRight places the leaves along the Y axis
+	// with the root to their right.
+	DendrogramRight
+)
+
+// vertical returns whether the leaves are placed along the X axis.
+func (o DendrogramOrientation) vertical() bool {
+	return o == DendrogramTop || o == DendrogramBottom
+}
+
+// coord returns the coordinate along the height axis of the height h,
+// which is negated when the root is below or to the left of the leaves.
+func (o DendrogramOrientation) coord(h float64) float64 {
+	if o == DendrogramBottom || o == DendrogramLeft {
+		return -h
+	}
+	return h
+}
+
+// Dendrogram implements the Plotter interface, drawing the tree
+// of a hierarchical clustering. The leaves are placed at the integer
+// locations of the leaf axis in the order given by LeafOrder, so that
+// the leaf at index k of LeafOrder is drawn at k, and the merges are
+// placed at their heights along the other axis.
+type Dendrogram struct {
+	// Merges are the steps of the clustering.
+	Merges []Merge
+
+	// Labels are the labels of the leaves, indexed
+	// by leaf identifier. If Labels is nil, no leaf
+	// labels are drawn.
+	Labels []string
+
+	// Orientation specifies the placement of the tree.
+	Orientation DendrogramOrientation
+
+	// LineStyle is the style of the links of the tree.
+	LineStyle draw.LineStyle
+
+	// ColorThreshold is the height below which the
+	// links of each maximal subtree are drawn in a
+	// color of their own taken from ClusterColors. If
+	// ColorThreshold is not positive, all links are
+	// drawn with LineStyle.
+	ColorThreshold float64
+
+	// ClusterColors are the colors used for subtrees
+	// below ColorThreshold. They are used in turn in
+	// leaf order and reused when exhausted.
+	ClusterColors []color.Color
+
+	// TextStyle is the style of the leaf labels. The
+	// text alignment is set according to Orientation.
+	TextStyle draw.TextStyle
+
+	// LabelGap is the distance between the end of
+	// a leaf and its label.
+	LabelGap vg.Length
+}
+
+// NewDendrogram returns a Dendrogram for the given clustering,
+// using the default line style and font. An error is returned if
+// the merges do not describe a single tree over their leaves, if
+// a height is invalid or if the number of labels does not match
+// the number of leaves.
+func NewDendrogram(merges []Merge, labels []string) (*Dendrogram, error) {
+	if len(merges) == 0 {
+		return nil, ErrNoData
+	}
+	n := len(merges) + 1
+	if labels != nil && len(labels) != n {
+		return nil, fmt.Errorf("plotter: number of labels (%d) does not match number of leaves (%d)", len(labels), n)
+	}
+
+	height := make([]float64, 2*n-1)
+	used := make([]bool, 2*n-1)
+	for i, m := range merges {
+		if err := CheckFinite(m.Height); err != nil {
+			return nil, err
+		}
+		if m.Height < 0 {
+			return nil, fmt.Errorf("plotter: merge %d has invalid height %g", i, m.Height)
+		}
+		for _, id := range []int{m.A, m.B} {
+			if id < 0 || id >= n+i {
+				return nil, fmt.Errorf("plotter: merge %d refers to unknown cluster %d", i, id)
+			}
+			if used[id] {
+				return nil, fmt.Errorf("plotter: merge %d reuses cluster %d", i, id)
+			}
+			used[id] = true
+			if height[id] > m.Height {
+				return nil, fmt.Errorf("plotter: merge %d height %g below height of cluster %d", i, m.Height, id)
+			}
+		}
+		height[n+i] = m.Height
+	}
+
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Dendrogram{
+		Merges:    append([]Merge(nil), merges...),
+		Labels:    append([]string(nil), labels...),
+		LineStyle: DefaultLineStyle,
+		ClusterColors: []color.Color{
+			color.RGBA{R: 31, G: 119, B: 180, A: 255},
+			color.RGBA{R: 255, G: 127, B: 14, A: 255},
+			color.RGBA{R: 44, G: 160, B: 44, A: 255},
+			color.RGBA{R: 214, G: 39, B: 40, A: 255},
+			color.RGBA{R: 148, G: 103, B: 189, A: 255},
+		},
+		TextStyle: draw.TextStyle{Font: fnt},
+		LabelGap:  vg.Points(2),
+	}, nil
+}
+
+// leaves returns the number of leaves of the dendrogram.
+func (d *Dendrogram) leaves() int {
+	return len(d.Merges) + 1
+}
+
+// LeafOrder returns the leaf identifiers in the order
+// they are drawn along the leaf axis.
+func (d *Dendrogram) LeafOrder() []int {
+	n := d.leaves()
+	order := make([]int, 0, n)
+	var walk func(id int)
+	walk = func(id int) {
+		if id < n {
+			order = append(order, id)
+			return
+		}
+		m := d.Merges[id-n]
+		walk(m.A)
+		walk(m.B)
+	}
+	walk(2*n - 2)
+	return order
+}
+
+// layout returns the leaf axis location and the height of
+// every cluster of the dendrogram, indexed by identifier.
+func (d *Dendrogram) layout() (loc, height []float64) {
+	n := d.leaves()
+	loc = make([]float64, 2*n-1)
+	height = make([]float64, 2*n-1)
+	for k, id := range d.LeafOrder() {
+		loc[id] = float64(k)
+	}
+	for i, m := range d.Merges {
+		loc[n+i] = (loc[m.A] + loc[m.B]) / 2
+		height[n+i] = m.Height
+	}
+	return loc, height
+}
+
+// clusters returns the index of the cluster color of every
+// cluster of the dendrogram, indexed by identifier, or -1
+// for clusters that are not below ColorThreshold.
+func (d *Dendrogram) clusters() []int {
+	n := d.leaves()
+	col := make([]int, 2*n-1)
+	for i := range col {
+		col[i] = -1
+	}
+	if d.ColorThreshold <= 0 {
+		return col
+	}
+	next := 0
+	var walk func(id, c int)
+	walk = func(id, c int) {
+		if id < n {
+			col[id] = c
+			return
+		}
+		m := d.Merges[id-n]
+		if c < 0 && m.Height < d.ColorThreshold {
+			c = next
+			next++
+		}
+		col[id] = c
+		walk(m.A, c)
+		walk(m.B, c)
+	}
+	walk(2*n-2, -1)
+	return col
+}
+
+// point returns the canvas location of the given
+// leaf axis location and height.
+func (d *Dendrogram) point(trX, trY func(float64) vg.Length, loc, height float64) vg.Point {
+	height = d.Orientation.coord(height)
+	if d.Orientation.vertical() {
+		return vg.Point{X: trX(loc), Y: trY(height)}
+	}
+	return vg.Point{X: trX(height), Y: trY(loc)}
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (d *Dendrogram) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	loc, height := d.layout()
+	cluster := d.clusters()
+	n := d.leaves()
+
+	for i, m := range d.Merges {
+		sty := d.LineStyle
+		if k := cluster[n+i]; k >= 0 && len(d.ClusterColors) > 0 {
+			sty.Color = d.ClusterColors[k%len(d.ClusterColors)]
+		}
+		pts := []vg.Point{
+			d.point(trX, trY, loc[m.A], height[m.A]),
+			d.point(trX, trY, loc[m.A], m.Height),
+			d.point(trX, trY, loc[m.B], m.Height),
+			d.point(trX, trY, loc[m.B], height[m.B]),
+		}
+		c.StrokeLines(sty, c.ClipLinesXY(pts)...)
+	}
+
+	if d.Labels == nil {
+		return
+	}
+	sty, off := d.labelStyle(plt)
+	for id, lbl := range d.Labels {
+		pt := d.point(trX, trY, loc[id], 0)
+		if !c.Contains(pt) {
+			continue
+		}
+		c.FillText(sty, pt.Add(off), lbl)
+	}
+}
+
+// labelStyle returns the text style of the leaf labels and their
+// offset from the end of the leaves. The labels are placed on the
+// opposite side of the leaves from the root.
+func (d *Dendrogram) labelStyle(plt *plot.Plot) (draw.TextStyle, vg.Point) {
+	sty := d.TextStyle
+	_, maxHeight := d.heightRange()
+	maxHeight = d.Orientation.coord(maxHeight)
+	if d.Orientation.vertical() {
+		sty.XAlign = draw.XCenter
+		if plt.Y.Norm(maxHeight) >= plt.Y.Norm(0) {
+			sty.YAlign = draw.YTop
+			return sty, vg.Point{Y: -d.LabelGap}
+		}
+		sty.YAlign = draw.YBottom
+		return sty, vg.Point{Y: d.LabelGap}
+	}
+	sty.YAlign = draw.YCenter
+	if plt.X.Norm(maxHeight) >= plt.X.Norm(0) {
+		sty.XAlign = draw.XRight
+		return sty, vg.Point{X: -d.LabelGap}
+	}
+	sty.XAlign = draw.XLeft
+	return sty, vg.Point{X: d.LabelGap}
+}
+
+// heightRange returns the range of heights of the dendrogram.
+func (d *Dendrogram) heightRange() (min, max float64) {
+	for _, m := range d.Merges {
+		max = math.Max(max, m.Height)
+	}
+	return 0, max
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (d *Dendrogram) DataRange() (xmin, xmax, ymin, ymax float64) {
+	hmin, hmax := d.heightRange()
+	if d.Orientation.coord(1) < 0 {
+		hmin, hmax = -hmax, -hmin
+	}
+	lmin, lmax := 0.0, float64(d.leaves()-1)
+	if d.Orientation.vertical() {
+		return lmin, lmax, hmin, hmax
+	}
+	return hmin, hmax, lmin, lmax
+}
+
+// GlyphBoxes implements the GlyphBoxes method
+// of the plot.GlyphBoxer interface, returning a
+// box for each leaf label.
+func (d *Dendrogram) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	if d.Labels == nil {
+		return nil
+	}
+	loc, _ := d.layout()
+	sty, off := d.labelStyle(plt)
+	boxes := make([]plot.GlyphBox, len(d.Labels))
+	for id, lbl := range d.Labels {
+		r := sty.Rectangle(lbl)
+		r.Min = r.Min.Add(off)
+		r.Max = r.Max.Add(off)
+		boxes[id].Rectangle = r
+		if d.Orientation.vertical() {
+			boxes[id].X = plt.X.Norm(loc[id])
+			boxes[id].Y = plt.Y.Norm(0)
+		} else {
+			boxes[id].X = plt.X.Norm(0)
+			boxes[id].Y = plt.Y.Norm(loc[id])
+		}
+	}
+	return boxes
+}
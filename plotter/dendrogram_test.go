@@ -0,0 +1,101 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+var dendrogramTests = []struct {
+	merges []Merge
+
+	wantOrder    []int
+	wantLoc      []float64
+	threshold    float64
+	wantClusters []int
+}{
+	{
+		merges:       []Merge{{A: 0, B: 1, Height: 1}},
+		wantOrder:    []int{0, 1},
+		wantLoc:      []float64{0, 1, 0.5},
+		threshold:    2,
+		wantClusters: []int{0, 0, 0},
+	},
+	{
+		merges: []Merge{
+			{A: 0, B: 3, Height: 1},
+			{A: 1, B: 4, Height: 1.5},
+			{A: 2, B: 5, Height: 4},
+			{A: 6, B: 7, Height: 6},
+		},
+		wantOrder:    []int{1, 4, 2, 0, 3},
+		wantLoc:      []float64{3, 0, 2, 4, 1, 3.5, 0.5, 2.75, 1.625},
+		threshold:    3,
+		wantClusters: []int{1, 0, -1, 1, 0, 1, 0, -1, -1},
+	},
+}
+
+func TestDendrogramLayout(t *testing.T) {
+	for i, test := range dendrogramTests {
+		d, err := NewDendrogram(test.merges, nil)
+		if err != nil {
+			t.Fatalf("unexpected error for test %d: %v", i, err)
+		}
+		if got := d.LeafOrder(); !reflect.DeepEqual(got, test.wantOrder) {
+			t.Errorf("unexpected leaf order for test %d: got:%v want:%v", i, got, test.wantOrder)
+		}
+		if got, _ := d.layout(); !reflect.DeepEqual(got, test.wantLoc) {
+			t.Errorf("unexpected locations for test %d: got:%v want:%v", i, got, test.wantLoc)
+		}
+		d.ColorThreshold = test.threshold
+		if got := d.clusters(); !reflect.DeepEqual(got, test.wantClusters) {
+			t.Errorf("unexpected clusters for test %d: got:%v want:%v", i, got, test.wantClusters)
+		}
+	}
+}
+
+func TestDendrogramDataRange(t *testing.T) {
+	d, err := NewDendrogram(dendrogramTests[1].merges, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, test := range []struct {
+		orientation            DendrogramOrientation
+		xmin, xmax, ymin, ymax float64
+	}{
+		{orientation: DendrogramTop, xmax: 4, ymax: 6},
+		{orientation: DendrogramBottom, xmax: 4, ymin: -6},
+		{orientation: DendrogramLeft, xmin: -6, ymax: 4},
+		{orientation: DendrogramRight, xmax: 6, ymax: 4},
+	} {
+		d.Orientation = test.orientation
+		xmin, xmax, ymin, ymax := d.DataRange()
+		if xmin != test.xmin || xmax != test.xmax || ymin != test.ymin || ymax != test.ymax {
+			t.Errorf("unexpected data range for orientation %d: got:%v %v %v %v want:%v %v %v %v",
+				test.orientation, xmin, xmax, ymin, ymax, test.xmin, test.xmax, test.ymin, test.ymax)
+		}
+	}
+}
+
+func TestNewDendrogramErrors(t *testing.T) {
+	for _, test := range []struct {
+		merges []Merge
+		labels []string
+	}{
+		{merges: nil},
+		{merges: []Merge{{A: 0, B: 1, Height: 1}}, labels: []string{"a"}},
+		{merges: []Merge{{A: 0, B: 2, Height: 1}}},
+		{merges: []Merge{{A: 0, B: 0, Height: 1}}},
+		{merges: []Merge{{A: 0, B: 1, Height: -1}}},
+		{merges: []Merge{{A: 0, B: 1, Height: math.NaN()}}},
+		{merges: []Merge{{A: 0, B: 1, Height: 2}, {A: 2, B: 3, Height: 1}}},
+	} {
+		if _, err := NewDendrogram(test.merges, test.labels); err == nil {
+			t.Errorf("expected error for merges %+v labels %q", test.merges, test.labels)
+		}
+	}
+}
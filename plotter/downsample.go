@@ -0,0 +1,194 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/draw"
+)
+
+// Downsampling specifies how the points of a Line
+// are reduced before they are drawn.
+type Downsampling int
+
+const (
+	// NoDownsampling draws every point.
+	NoDownsampling Downsampling = iota
+
+	// MinMaxDownsampling keeps the first, last, lowest
+	// and highest point of each column of a fraction of
+	// a pixel of the output, so that the drawn line
+	// covers the same pixels as the full line.
+	MinMaxDownsampling
+
+	// LTTBDownsampling keeps two points per column
+	// chosen by the largest-triangle-three-buckets
+	// algorithm, which retains the visual shape of the
+	// line with fewer points than MinMaxDownsampling.
+	LTTBDownsampling
+)
+
+// DefaultDownsamplingDPI is the resolution used for downsampling when
+// the resolution of the output is not known, as is the case for vector
+// formats.
+const DefaultDownsamplingDPI = 300
+
+// columnsPerPixel is the number of downsampling columns in each
+// pixel of the output. Using columns narrower than a pixel keeps
+// the anti-aliased edges of downsampled lines faithful.
+const columnsPerPixel = 4
+
+// dpier is implemented by canvases that have a resolution.
+type dpier interface {
+	DPI() float64
+}
+
+// columnWidth returns the width of the columns used for
+// downsampling on the canvas c at the resolution dpi. If dpi is
+// not positive the resolution of the canvas is used when it is
+// known and DefaultDownsamplingDPI otherwise.
+func columnWidth(c draw.Canvas, dpi float64) vg.Length {
+	if dpi <= 0 {
+		dpi = DefaultDownsamplingDPI
+		if d, ok := c.Canvas.(dpier); ok && d.DPI() > 0 {
+			dpi = d.DPI()
+		}
+	}
+	return vg.Inch / vg.Length(dpi*columnsPerPixel)
+}
+
+// downsample returns the canvas points ps reduced by the method d
+// for columns of width w starting at x0. Points with a NaN
+// coordinate break the line and are retained. If the X coordinates
+// of the points are not in non-decreasing order, ps is returned
+// unaltered.
+func downsample(d Downsampling, ps []vg.Point, x0, w vg.Length) []vg.Point {
+	if d == NoDownsampling || w <= 0 || !sortedX(ps) {
+		return ps
+	}
+	var out []vg.Point
+	for len(ps) > 0 {
+		// Split the points at gaps.
+		n := 0
+		for n < len(ps) && !isNaNPoint(ps[n]) {
+			n++
+		}
+		switch d {
+		case MinMaxDownsampling:
+			out = minMaxColumns(out, ps[:n], x0, w)
+		case LTTBDownsampling:
+			if n > 0 {
+				cols := int(math.Ceil(float64((ps[n-1].X - ps[0].X) / w)))
+				out = append(out, lttb(ps[:n], 2*cols+2)...)
+			}
+		default:
+			panic("plotter: unknown downsampling")
+		}
+		if n < len(ps) {
+			out = append(out, ps[n])
+			n++
+		}
+		ps = ps[n:]
+	}
+	return out
+}
+
+// isNaNPoint returns whether either coordinate of p is NaN.
+func isNaNPoint(p vg.Point) bool {
+	return math.IsNaN(float64(p.X)) || math.IsNaN(float64(p.Y))
+}
+
+// sortedX returns whether the X coordinates of the points of ps
+// that are not NaN are in non-decreasing order.
+func sortedX(ps []vg.Point) bool {
+	last := vg.Length(math.Inf(-1))
+	for _, p := range ps {
+		if isNaNPoint(p) {
+			continue
+		}
+		if p.X < last {
+			return false
+		}
+		last = p.X
+	}
+	return true
+}
+
+// minMaxColumns appends to dst the first, lowest, highest and last
+// points of ps in each column of width w starting at x0, in
+// their original order.
+func minMaxColumns(dst, ps []vg.Point, x0, w vg.Length) []vg.Point {
+	for len(ps) > 0 {
+		col := math.Floor(float64((ps[0].X - x0) / w))
+		n, lo, hi := 1, 0, 0
+		for ; n < len(ps) && math.Floor(float64((ps[n].X-x0)/w)) == col; n++ {
+			if ps[n].Y < ps[lo].Y {
+				lo = n
+			}
+			if ps[n].Y > ps[hi].Y {
+				hi = n
+			}
+		}
+		keep := []int{0, lo, hi, n - 1}
+		if lo > hi {
+			keep[1], keep[2] = hi, lo
+		}
+		for i, k := range keep {
+			if i == 0 || k != keep[i-1] {
+				dst = append(dst, ps[k])
+			}
+		}
+		ps = ps[n:]
+	}
+	return dst
+}
+
+// lttb returns at most n points of ps selected by the
+// largest-triangle-three-buckets algorithm of Steinarsson. The
+// first and last points are always retained.
+func lttb(ps []vg.Point, n int) []vg.Point {
+	if n >= len(ps) || n < 3 {
+		return ps
+	}
+	out := make([]vg.Point, 0, n)
+	out = append(out, ps[0])
+
+	// The points between the first and the last
+	// are divided into n-2 buckets.
+	size := float64(len(ps)-2) / float64(n-2)
+	a := 0
+	for i := 0; i < n-2; i++ {
+		start := int(float64(i)*size) + 1
+		end := int(float64(i+1)*size) + 1
+
+		// The third vertex of the triangles is the
+		// mean of the next bucket.
+		nextStart, nextEnd := end, int(float64(i+2)*size)+1
+		if nextEnd > len(ps) {
+			nextEnd = len(ps)
+		}
+		if i == n-3 {
+			nextStart, nextEnd = len(ps)-1, len(ps)
+		}
+		var mean vg.Point
+		for _, p := range ps[nextStart:nextEnd] {
+			mean = mean.Add(p)
+		}
+		mean = mean.Scale(1 / vg.Length(nextEnd-nextStart))
+
+		best, bestArea := start, -1.0
+		for j := start; j < end; j++ {
+			area := math.Abs(float64((ps[a].X-mean.X)*(ps[j].Y-ps[a].Y) - (ps[a].X-ps[j].X)*(mean.Y-ps[a].Y)))
+			if area > bestArea {
+				best, bestArea = j, area
+			}
+		}
+		out = append(out, ps[best])
+		a = best
+	}
+	return append(out, ps[len(ps)-1])
+}
@@ -0,0 +1,142 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/draw"
+	"github.com/gshk/plot/vg/vgimg"
+)
+
+func TestMinMaxColumns(t *testing.T) {
+	ps := []vg.Point{
+		{X: 0.1, Y: 5}, {X: 0.2, Y: 9}, {X: 0.3, Y: 1}, {X: 0.4, Y: 4}, {X: 0.5, Y: 3},
+		{X: 1.5, Y: 2},
+		{X: 2.1, Y: 0}, {X: 2.9, Y: 8},
+	}
+	got := minMaxColumns(nil, ps, 0, 1)
+	want := []vg.Point{
+		{X: 0.1, Y: 5}, {X: 0.2, Y: 9}, {X: 0.3, Y: 1}, {X: 0.5, Y: 3},
+		{X: 1.5, Y: 2},
+		{X: 2.1, Y: 0}, {X: 2.9, Y: 8},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected number of points: got:%v want:%v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("unexpected point %d: got:%v want:%v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestLTTB(t *testing.T) {
+	ps := make([]vg.Point, 1000)
+	for i := range ps {
+		ps[i] = vg.Point{X: vg.Length(i), Y: vg.Length(math.Sin(float64(i) / 50))}
+	}
+	ps[500].Y = 10
+
+	got := lttb(ps, 50)
+	if len(got) != 50 {
+		t.Fatalf("unexpected number of points: got:%d want:50", len(got))
+	}
+	if got[0] != ps[0] || got[len(got)-1] != ps[len(ps)-1] {
+		t.Errorf("end points not retained: got:%v and %v", got[0], got[len(got)-1])
+	}
+	var spike bool
+	for _, p := range got {
+		spike = spike || p == ps[500]
+	}
+	if !spike {
+		t.Error("spike not retained")
+	}
+}
+
+func TestDownsampleGaps(t *testing.T) {
+	nan := vg.Length(math.NaN())
+	ps := []vg.Point{{X: 0, Y: 0}, {X: 0.1, Y: 1}, {X: 0.2, Y: 0}, {X: nan, Y: nan}, {X: 0.3, Y: 2}, {X: 0.4, Y: 3}}
+	for _, d := range []Downsampling{MinMaxDownsampling, LTTBDownsampling} {
+		got := downsample(d, ps, 0, 1)
+		var gaps int
+		for _, p := range got {
+			if isNaNPoint(p) {
+				gaps++
+			}
+		}
+		if gaps != 1 {
+			t.Errorf("unexpected number of gaps for downsampling %d: got:%d want:1", d, gaps)
+		}
+	}
+
+	unsorted := []vg.Point{{X: 1}, {X: 0}, {X: 0.5}}
+	if got := downsample(MinMaxDownsampling, unsorted, 0, 10); len(got) != len(unsorted) {
+		t.Errorf("unsorted points were downsampled: got:%v", got)
+	}
+}
+
+func TestLineDownsamplingImage(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	xys := make(XYs, 200000)
+	var y float64
+	for i := range xys {
+		y += rnd.NormFloat64()
+		xys[i] = XY{X: float64(i), Y: y}
+	}
+
+	render := func(d Downsampling) image.Image {
+		l, err := NewLine(xys)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		l.Downsampling = d
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.HideAxes()
+		p.Add(l)
+		c := vgimg.New(4*vg.Inch, 3*vg.Inch)
+		p.Draw(draw.New(c))
+		return c.Image()
+	}
+
+	// Allow a small fraction of pixels to differ
+	// markedly due to anti-aliasing.
+	full := render(NoDownsampling)
+	for _, test := range []struct {
+		d   Downsampling
+		tol float64
+	}{
+		{d: MinMaxDownsampling, tol: 0.005},
+		{d: LTTBDownsampling, tol: 0.01},
+	} {
+		got := render(test.d)
+		var diff, ink int
+		b := full.Bounds()
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				r0, _, _, _ := full.At(x, y).RGBA()
+				r1, _, _, _ := got.At(x, y).RGBA()
+				if r0 != 0xffff {
+					ink++
+				}
+				if math.Abs(float64(r0)-float64(r1)) > 0xc000 {
+					diff++
+				}
+			}
+		}
+		if frac := float64(diff) / float64(ink); frac > test.tol {
+			t.Errorf("unexpected difference for downsampling %d: %d of %d inked pixels differ", test.d, diff, ink)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/draw"
+)
+
+// ellipseSegments is the number of line segments
+// used to approximate each ellipse.
+const ellipseSegments = 72
+
+// Covariance is the covariance matrix of a two-dimensional
+// distribution of X and Y values.
+type Covariance struct {
+	XX, XY, YY float64
+}
+
+// CovarianceOf returns the Covariance held in the 2×2 symmetric
+// matrix m.
+func CovarianceOf(m mat.Symmetric) (Covariance, error) {
+	if m.Symmetric() != 2 {
+		return Covariance{}, errors.New("plotter: covariance matrix is not 2×2")
+	}
+	return Covariance{XX: m.At(0, 0), XY: m.At(0, 1), YY: m.At(1, 1)}, nil
+}
+
+// axes returns the standard deviations along the major and minor
+// principal axes of the covariance, and the angle of the major axis
+// from the X axis in radians.
+func (cv Covariance) axes() (major, minor, angle float64) {
+	mean := (cv.XX + cv.YY) / 2
+	d := math.Hypot((cv.XX-cv.YY)/2, cv.XY)
+	// Rounding may make the smaller eigenvalue of a
+	// singular matrix slightly negative.
+	major = math.Sqrt(math.Max(0, mean+d))
+	minor = math.Sqrt(math.Max(0, mean-d))
+	angle = math.Atan2(2*cv.XY, cv.XX-cv.YY) / 2
+	return major, minor, angle
+}
+
+// check returns an error if the covariance is not finite
+// and positive semi-definite.
+func (cv Covariance) check() error {
+	if err := CheckFinite(cv.XX, cv.XY, cv.YY); err != nil {
+		return err
+	}
+	if cv.XX < 0 || cv.YY < 0 || cv.XX*cv.YY-cv.XY*cv.XY < -1e-12*cv.XX*cv.YY {
+		return errors.New("plotter: covariance is not positive semi-definite")
+	}
+	return nil
+}
+
+// ProbabilityLevel returns the level of the ellipse of a
+// two-dimensional normal distribution that contains the
+// fraction p of the probability, for use in the Levels of
+// CovarianceEllipses. For example, ProbabilityLevel(0.95)
+// is about 2.45.
+func ProbabilityLevel(p float64) float64 {
+	return math.Sqrt(-2 * math.Log(1-p))
+}
+
+// CovarianceEllipses implements the Plotter interface, drawing
+// ellipses of constant probability density of two-dimensional
+// normal distributions, such as the confidence regions of
+// estimates of a position.
+type CovarianceEllipses struct {
+	// XYs is a copy of the centers of the ellipses.
+	XYs
+
+	// Covariances are the covariances of the
+	// distributions about each center.
+	Covariances []Covariance
+
+	// Levels are the sizes of the ellipses drawn for
+	// each distribution as the number of standard
+	// deviations, or Mahalanobis distance, from the
+	// center. ProbabilityLevel returns the level of
+	// an ellipse containing a given probability.
+	Levels []float64
+
+	// LineStyle is the style of the ellipses.
+	draw.LineStyle
+
+	// FillColor is the color used to fill the ellipses.
+	// Ellipses are filled from the largest level down,
+	// so a translucent color shades the inner regions
+	// more strongly. If FillColor is nil the ellipses
+	// are not filled.
+	FillColor color.Color
+
+	// Axes specifies that the principal axes of the
+	// largest ellipse of each distribution are drawn.
+	Axes bool
+
+	// AxesStyle is the style of the principal axes.
+	AxesStyle draw.LineStyle
+}
+
+// NewCovarianceEllipses returns CovarianceEllipses for the
+// distributions with the given centers and covariances, drawn at
+// the given levels in standard deviations. If no levels are given
+// ellipses are drawn at one and two standard deviations. An error
+// is returned if the number of centers and covariances differ, if
+// any of the values are not finite, or if any covariance is not
+// positive semi-definite.
+func NewCovarianceEllipses(centers XYer, covs []Covariance, levels ...float64) (*CovarianceEllipses, error) {
+	data, err := CopyXYs(centers)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != len(covs) {
+		return nil, errors.New("plotter: number of centers and covariances differ")
+	}
+	for i, p := range data {
+		if err := CheckFinite(p.X, p.Y); err != nil {
+			return nil, err
+		}
+		if err := covs[i].check(); err != nil {
+			return nil, err
+		}
+	}
+	if len(levels) == 0 {
+		levels = []float64{1, 2}
+	}
+	for _, l := range levels {
+		if !(l > 0) || math.IsInf(l, 1) {
+			return nil, errors.New("plotter: invalid ellipse level")
+		}
+	}
+	axes := DefaultLineStyle
+	axes.Dashes = []vg.Length{vg.Points(2), vg.Points(2)}
+	return &CovarianceEllipses{
+		XYs:         data,
+		Covariances: append([]Covariance(nil), covs...),
+		Levels:      append([]float64(nil), levels...),
+		LineStyle:   DefaultLineStyle,
+		AxesStyle:   axes,
+	}, nil
+}
+
+// maxLevel returns the largest of the levels.
+func (e *CovarianceEllipses) maxLevel() float64 {
+	var max float64
+	for _, l := range e.Levels {
+		max = math.Max(max, l)
+	}
+	return max
+}
+
+// ellipse returns the points of the ellipse at the level k of the
+// ith distribution in data coordinates.
+func (e *CovarianceEllipses) ellipse(i int, k float64) XYs {
+	major, minor, angle := e.Covariances[i].axes()
+	sin, cos := math.Sincos(angle)
+	pts := make(XYs, ellipseSegments+1)
+	for j := range pts {
+		st, ct := math.Sincos(2 * math.Pi * float64(j) / ellipseSegments)
+		u, v := k*major*ct, k*minor*st
+		pts[j] = XY{X: e.XYs[i].X + u*cos - v*sin, Y: e.XYs[i].Y + u*sin + v*cos}
+	}
+	return pts
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+// The ellipses are computed in data coordinates, so they are
+// distorted appropriately on non-linear axes.
+func (e *CovarianceEllipses) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	levels := append([]float64(nil), e.Levels...)
+	sort.Sort(sort.Reverse(sort.Float64Slice(levels)))
+	for i := range e.XYs {
+		for _, k := range levels {
+			pts := e.ellipse(i, k)
+			ps := make([]vg.Point, len(pts))
+			for j, p := range pts {
+				ps[j] = vg.Point{X: trX(p.X), Y: trY(p.Y)}
+			}
+			if e.FillColor != nil {
+				c.FillPolygon(e.FillColor, c.ClipPolygonXY(ps))
+			}
+			c.StrokeLines(e.LineStyle, c.ClipLinesXY(ps)...)
+		}
+		if !e.Axes || len(levels) == 0 {
+			continue
+		}
+		major, minor, angle := e.Covariances[i].axes()
+		sin, cos := math.Sincos(angle)
+		ctr := e.XYs[i]
+		for _, a := range []struct{ l, dx, dy float64 }{
+			{l: levels[0] * major, dx: cos, dy: sin},
+			{l: levels[0] * minor, dx: -sin, dy: cos},
+		} {
+			from := vg.Point{X: trX(ctr.X - a.l*a.dx), Y: trY(ctr.Y - a.l*a.dy)}
+			to := vg.Point{X: trX(ctr.X + a.l*a.dx), Y: trY(ctr.Y + a.l*a.dy)}
+			c.StrokeLines(e.AxesStyle, c.ClipLinesXY([]vg.Point{from, to})...)
+		}
+	}
+}
+
+// bounds returns the half-widths of the bounding box of the
+// largest ellipse of the ith distribution.
+func (e *CovarianceEllipses) bounds(i int) (dx, dy float64) {
+	k := e.maxLevel()
+	cv := e.Covariances[i]
+	return k * math.Sqrt(cv.XX), k * math.Sqrt(cv.YY)
+}
+
+// DataRange implements the DataRange method of the
+// plot.DataRanger interface. The range includes the
+// bounding boxes of the largest ellipses.
+func (e *CovarianceEllipses) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, ymin = math.Inf(1), math.Inf(1)
+	xmax, ymax = math.Inf(-1), math.Inf(-1)
+	for i, p := range e.XYs {
+		dx, dy := e.bounds(i)
+		xmin = math.Min(xmin, p.X-dx)
+		xmax = math.Max(xmax, p.X+dx)
+		ymin = math.Min(ymin, p.Y-dy)
+		ymax = math.Max(ymax, p.Y+dy)
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// GlyphBoxes implements the GlyphBoxes method of the
+// plot.GlyphBoxer interface. The boxes hold the stroke
+// widths at the extremes of the largest ellipses, so that
+// the lines are not clipped at the edges of the data area.
+func (e *CovarianceEllipses) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	w := e.LineStyle.Width / 2
+	r := vg.Rectangle{Min: vg.Point{X: -w, Y: -w}, Max: vg.Point{X: w, Y: w}}
+	bs := make([]plot.GlyphBox, 0, 4*len(e.XYs))
+	for i, p := range e.XYs {
+		dx, dy := e.bounds(i)
+		for _, q := range []XY{{X: p.X - dx, Y: p.Y}, {X: p.X + dx, Y: p.Y}, {X: p.X, Y: p.Y - dy}, {X: p.X, Y: p.Y + dy}} {
+			bs = append(bs, plot.GlyphBox{
+				X:         plt.X.Norm(q.X),
+				Y:         plt.Y.Norm(q.Y),
+				Rectangle: r,
+			})
+		}
+	}
+	return bs
+}
+
+// Thumbnail implements the Thumbnail method of the
+// plot.Thumbnailer interface.
+func (e *CovarianceEllipses) Thumbnail(c *draw.Canvas) {
+	ctr := c.Center()
+	rx, ry := (c.Max.X-c.Min.X)/2, (c.Max.Y-c.Min.Y)/2
+	ps := make([]vg.Point, ellipseSegments+1)
+	for j := range ps {
+		st, ct := math.Sincos(2 * math.Pi * float64(j) / ellipseSegments)
+		ps[j] = vg.Point{X: ctr.X + rx*vg.Length(ct), Y: ctr.Y + ry*vg.Length(st)}
+	}
+	if e.FillColor != nil {
+		c.FillPolygon(e.FillColor, c.ClipPolygonXY(ps))
+	}
+	c.StrokeLines(e.LineStyle, c.ClipLinesXY(ps)...)
+}
@@ -0,0 +1,107 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter_test
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/cmpimg"
+	"github.com/gshk/plot/plotter"
+)
+
+// ExampleCovarianceEllipses draws the 50% and 95% confidence
+// regions of a sequence of position estimates, such as the
+// states of a Kalman filter, with their principal axes.
+func ExampleCovarianceEllipses() {
+	track := plotter.XYs{{X: 0, Y: 0}, {X: 3, Y: 1}, {X: 6, Y: 3}, {X: 9, Y: 6}}
+	covs := []plotter.Covariance{
+		{XX: 0.2, XY: 0, YY: 0.2},
+		{XX: 0.6, XY: 0.3, YY: 0.4},
+		{XX: 1.2, XY: 0.8, YY: 0.9},
+		{XX: 2, XY: 1.5, YY: 1.6},
+	}
+
+	e, err := plotter.NewCovarianceEllipses(track, covs, plotter.ProbabilityLevel(0.5), plotter.ProbabilityLevel(0.95))
+	if err != nil {
+		log.Panic(err)
+	}
+	e.FillColor = color.NRGBA{B: 255, A: 48}
+	e.Axes = true
+
+	l, err := plotter.NewLine(track)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Position estimates"
+	p.Add(e, l)
+
+	err = p.Save(200, 200, "testdata/covarianceEllipses.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestCovarianceEllipsesExample(t *testing.T) {
+	cmpimg.CheckPlot(ExampleCovarianceEllipses, t, "covarianceEllipses.png")
+}
+
+func TestCovarianceEllipses(t *testing.T) {
+	cv, err := plotter.CovarianceOf(mat.NewSymDense(2, []float64{4, 0, 0, 1}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	e, err := plotter.NewCovarianceEllipses(plotter.XYs{{X: 1, Y: 2}}, []plotter.Covariance{cv}, 1, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	xmin, xmax, ymin, ymax := e.DataRange()
+	if xmin != -5 || xmax != 7 || ymin != -1 || ymax != 5 {
+		t.Errorf("unexpected data range: got:%v %v %v %v want:-5 7 -1 5", xmin, xmax, ymin, ymax)
+	}
+
+	// The bounding box of a rotated ellipse is given
+	// by the marginal standard deviations.
+	e, err = plotter.NewCovarianceEllipses(plotter.XYs{{}}, []plotter.Covariance{{XX: 1, XY: 0.9, YY: 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	xmin, xmax, ymin, ymax = e.DataRange()
+	if xmin != -2 || xmax != 2 || ymin != -2 || ymax != 2 {
+		t.Errorf("unexpected data range: got:%v %v %v %v want:-2 2 -2 2", xmin, xmax, ymin, ymax)
+	}
+
+	if got, want := plotter.ProbabilityLevel(0.95), 2.4477; math.Abs(got-want) > 1e-4 {
+		t.Errorf("unexpected probability level: got:%v want:%v", got, want)
+	}
+
+	if _, err := plotter.CovarianceOf(mat.NewSymDense(3, nil)); err == nil {
+		t.Error("expected error for 3×3 matrix")
+	}
+	for _, test := range []struct {
+		covs   []plotter.Covariance
+		levels []float64
+	}{
+		{covs: nil},
+		{covs: []plotter.Covariance{{XX: 1, XY: 2, YY: 1}}},
+		{covs: []plotter.Covariance{{XX: -1, YY: 1}}},
+		{covs: []plotter.Covariance{{XX: math.NaN(), YY: 1}}},
+		{covs: []plotter.Covariance{{XX: 1, YY: 1}}, levels: []float64{0}},
+	} {
+		if _, err := plotter.NewCovarianceEllipses(plotter.XYs{{}}, test.covs, test.levels...); err == nil {
+			t.Errorf("expected error for covariances %v levels %v", test.covs, test.levels)
+		}
+	}
+}
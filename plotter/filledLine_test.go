@@ -7,6 +7,7 @@ package plotter_test
 import (
 	"image/color"
 	"log"
+	"math"
 	"testing"
 
 	"golang.org/x/exp/rand"
@@ -14,6 +15,7 @@ import (
 	"github.com/gshk/plot"
 	"github.com/gshk/plot/cmpimg"
 	"github.com/gshk/plot/plotter"
+	"github.com/gshk/plot/vg"
 )
 
 // See https://github.com/gonum/plot/issues/488
@@ -102,6 +104,43 @@ func ExampleLine_filledLine() {
 	}
 }
 
+// This example shows the area below a line filled with
+// a gradient that fades out towards the bottom of the plot.
+func ExampleLine_gradient() {
+	pts := make(plotter.XYs, 50)
+	for i := range pts {
+		pts[i].X = float64(i) / 5
+		pts[i].Y = 2 + math.Sin(pts[i].X)*math.Exp(-pts[i].X/8)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Gradient Filled Line"
+	p.Y.Min = 0
+
+	line, err := plotter.NewLine(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+	line.Color = color.RGBA{B: 160, A: 255}
+	line.Gradient = []vg.GradientStop{
+		{Offset: 0, Color: color.NRGBA{B: 160, A: 0}},
+		{Offset: 1, Color: color.NRGBA{B: 160, A: 192}},
+	}
+	p.Add(line)
+
+	err = p.Save(300, 200, "testdata/gradientLine.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestLineGradient(t *testing.T) {
+	cmpimg.CheckPlot(ExampleLine_gradient, t, "gradientLine.png")
+}
+
 func TestFilledLine(t *testing.T) {
 	cmpimg.CheckPlot(ExampleLine_filledLine, t, "filledLine.png")
 	cmpimg.CheckPlot(clippedFilledLine, t, "clippedFilledLine.png")
@@ -57,6 +57,25 @@ type HeatMap struct {
 	// Min and Max define the dynamic range of the
 	// heat map.
 	Min, Max float64
+
+	// Annotate, if not nil, is used to format the
+	// value of each heat map element as text that is
+	// drawn centered in the element. Elements that are
+	// NaN are not annotated.
+	Annotate func(v float64) string
+
+	// AnnotationStyle is the style of the annotation
+	// text. If its Font is the zero value, the default
+	// font is used. If its Color is nil, black or white text
+	// is chosen for each element, whichever contrasts
+	// most with the fill color of the element.
+	AnnotationStyle draw.TextStyle
+
+	// MinAnnotatedSize is the minimum width and height
+	// of elements that are annotated. Annotations are
+	// also skipped for elements too small to hold their
+	// text.
+	MinAnnotatedSize vg.Length
 }
 
 // NewHeatMap creates as new heat map plotter for the given data,
@@ -93,6 +112,10 @@ func NewHeatMap(g GridXYZ, p palette.Palette) *HeatMap {
 		Palette: p,
 		Min:     min,
 		Max:     max,
+		AnnotationStyle: draw.TextStyle{
+			XAlign: draw.XCenter,
+			YAlign: draw.YCenter,
+		},
 	}
 }
 
@@ -110,6 +133,15 @@ func (h *HeatMap) Plot(c draw.Canvas, plt *plot.Plot) {
 
 	trX, trY := plt.Transforms(&c)
 
+	annSty := h.AnnotationStyle
+	if h.Annotate != nil && annSty.Font.Size == 0 {
+		fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+		if err != nil {
+			panic(err)
+		}
+		annSty.Font = fnt
+	}
+
 	var pa vg.Path
 	cols, rows := h.GridXYZ.Dims()
 	for i := 0; i < cols; i++ {
@@ -162,8 +194,9 @@ func (h *HeatMap) Plot(c draw.Canvas, plt *plot.Plot) {
 			pa.Line(vg.Point{X: x, Y: dy})
 			pa.Close()
 
+			v := h.GridXYZ.Z(i, j)
 			var col color.Color
-			switch v := h.GridXYZ.Z(i, j); {
+			switch {
 			case v < h.Min:
 				col = h.Underflow
 			case v > h.Max:
@@ -177,8 +210,62 @@ func (h *HeatMap) Plot(c draw.Canvas, plt *plot.Plot) {
 				c.SetColor(col)
 				c.Fill(pa)
 			}
+
+			if h.Annotate != nil && !math.IsNaN(v) {
+				h.annotate(c, annSty, vg.Rectangle{
+					Min: vg.Point{X: x, Y: y},
+					Max: vg.Point{X: dx, Y: dy},
+				}, v, col)
+			}
+		}
+	}
+}
+
+// annotate draws the annotation of the value v with the style sty
+// centered in the element r filled with the color col, if the element
+// is large enough to hold it.
+func (h *HeatMap) annotate(c draw.Canvas, sty draw.TextStyle, r vg.Rectangle, v float64, col color.Color) {
+	txt := h.Annotate(v)
+	if txt == "" {
+		return
+	}
+	w, ht := sty.Width(txt), sty.Height(txt)
+	size := r.Size()
+	size.X, size.Y = vg.Length(math.Abs(float64(size.X))), vg.Length(math.Abs(float64(size.Y)))
+	if size.X < h.MinAnnotatedSize || size.Y < h.MinAnnotatedSize || w > size.X || ht > size.Y {
+		return
+	}
+
+	if sty.Color == nil {
+		sty.Color = color.Black
+		if col != nil && luminance(col) < 0.179 {
+			sty.Color = color.White
+		}
+	}
+	c.FillText(sty, vg.Point{
+		X: (r.Min.X+r.Max.X)/2 - vg.Length(sty.XAlign+0.5)*w,
+		Y: (r.Min.Y+r.Max.Y)/2 - vg.Length(sty.YAlign+0.5)*ht,
+	}, txt)
+}
+
+// luminance returns the relative luminance of c as
+// defined by WCAG 2.0, ignoring transparency. Text on a
+// background with luminance below 0.179 has a higher
+// contrast ratio in white than in black.
+func luminance(c color.Color) float64 {
+	r, g, b, a := c.RGBA()
+	if a == 0 {
+		return 1
+	}
+	lin := func(v uint32) float64 {
+		// Undo alpha premultiplication and sRGB gamma.
+		f := float64(v) / float64(a)
+		if f <= 0.03928 {
+			return f / 12.92
 		}
+		return math.Pow((f+0.055)/1.055, 2.4)
 	}
+	return 0.2126*lin(r) + 0.7152*lin(g) + 0.0722*lin(b)
 }
 
 // DataRange implements the DataRange method
@@ -6,6 +6,7 @@ package plotter_test
 
 import (
 	"fmt"
+	"image/color"
 	"log"
 	"math"
 	"os"
@@ -18,6 +19,7 @@ import (
 	"github.com/gshk/plot/plotter"
 	"github.com/gshk/plot/vg"
 	"github.com/gshk/plot/vg/draw"
+	"github.com/gshk/plot/vg/recorder"
 	"github.com/gshk/plot/vg/vgimg"
 )
 
@@ -165,3 +167,74 @@ func TestHeatMapDims(t *testing.T) {
 		}()
 	}
 }
+
+// grayPalette is a palette of n grays from black to white.
+type grayPalette int
+
+func (p grayPalette) Colors() []color.Color {
+	c := make([]color.Color, p)
+	for i := range c {
+		c[i] = color.Gray{Y: uint8(255 * i / (len(c) - 1))}
+	}
+	return c
+}
+
+func TestHeatMapAnnotations(t *testing.T) {
+	m := offsetUnitGrid{
+		Data: mat.NewDense(2, 3, []float64{
+			0, 1, math.NaN(),
+			3, 4, 5,
+		})}
+	h := plotter.NewHeatMap(m, grayPalette(6))
+	h.NaN = color.Gray{Y: 128}
+	h.Annotate = func(v float64) string { return fmt.Sprint(v) }
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(h)
+
+	var rec recorder.Canvas
+	p.Draw(draw.NewCanvas(&rec, 300, 200))
+
+	got := make(map[string]color.Color)
+	var last color.Color
+	for _, a := range rec.Actions {
+		switch a := a.(type) {
+		case *recorder.SetColor:
+			last = a.Color
+		case *recorder.FillString:
+			got[a.String] = last
+		}
+	}
+	want := map[string]color.Color{
+		"0": color.White,
+		"1": color.White,
+		"3": color.Black,
+		"4": color.Black,
+		"5": color.Black,
+	}
+	for txt, col := range want {
+		c, ok := got[txt]
+		if !ok {
+			t.Errorf("missing annotation %q", txt)
+			continue
+		}
+		if c != col {
+			t.Errorf("unexpected color for annotation %q: got:%v want:%v", txt, c, col)
+		}
+	}
+	if _, ok := got["NaN"]; ok {
+		t.Error("unexpected annotation of NaN element")
+	}
+
+	rec.Reset()
+	h.MinAnnotatedSize = 1000
+	p.Draw(draw.NewCanvas(&rec, 300, 200))
+	for _, a := range rec.Actions {
+		if a, ok := a.(*recorder.FillString); ok && want[a.String] != nil {
+			t.Errorf("unexpected annotation %q below minimum size", a.String)
+		}
+	}
+}
@@ -21,7 +21,8 @@ type Histogram struct {
 	// Bins is the set of bins for this histogram.
 	Bins []HistogramBin
 
-	// Width is the width of each bin.
+	// Width is the width of each bin, or zero
+	// if the bins have different widths.
 	Width float64
 
 	// FillColor is the color used to fill each
@@ -29,6 +30,10 @@ type Histogram struct {
 	// then the bars are not filled.
 	FillColor color.Color
 
+	// Hatch is the hatch pattern drawn over the
+	// fill of each bar. The zero Hatch draws nothing.
+	Hatch vg.Hatch
+
 	// LineStyle is the style of the outline of each
 	// bar of the histogram.
 	draw.LineStyle
@@ -40,6 +45,23 @@ type Histogram struct {
 	// arbitrary amount of height for the smallest bin entry so it is visible
 	// on the final plot.
 	LogY bool
+
+	// Horizontal specifies that the bins of the histogram
+	// are placed along the Y axis with the bars extending
+	// along the X axis. When Horizontal is true, LogY
+	// applies to the X axis.
+	Horizontal bool
+
+	// Cumulative specifies that the bar of each bin
+	// shows the total weight of the bin and all of the
+	// bins before it.
+	Cumulative bool
+
+	// Outline specifies that the histogram is drawn as
+	// a single stepped outline around the bins rather
+	// than as a bar for each bin, which is clearer when
+	// several histograms are overlaid.
+	Outline bool
 }
 
 // NewHistogram returns a new histogram
@@ -71,6 +93,41 @@ func NewHist(vs Valuer, n int) (*Histogram, error) {
 	return NewHistogram(unitYs{vs}, n)
 }
 
+// NewHistogramRule returns a new histogram, as in
+// NewHistogram, with the number of bins given by
+// the rule r.
+func NewHistogramRule(xy XYer, r BinRule) (*Histogram, error) {
+	return NewHistogram(xy, r.Bins(XValues{xy}))
+}
+
+// NewHistogramEdges returns a new histogram that
+// represents the distribution of values using the
+// bins delimited by edges, which must be finite and
+// increasing. The bins may have different widths,
+// as are returned by LogEdges. Values outside the
+// edges are not counted.
+//
+// Each y value is assumed to be the frequency
+// count, or weight, of the corresponding x.
+func NewHistogramEdges(xy XYer, edges []float64) (*Histogram, error) {
+	if err := checkEdges(edges); err != nil {
+		return nil, err
+	}
+	return &Histogram{
+		Bins:      binEdges(xy, edges),
+		Width:     uniformWidth(edges),
+		FillColor: color.Gray{128},
+		LineStyle: DefaultLineStyle,
+	}, nil
+}
+
+// NewHistEdges returns a new histogram, as in
+// NewHistogramEdges, except that it accepts a
+// Valuer instead of an XYer.
+func NewHistEdges(vs Valuer, edges []float64) (*Histogram, error) {
+	return NewHistogramEdges(unitYs{vs}, edges)
+}
+
 type unitYs struct {
 	Valuer
 }
@@ -83,27 +140,79 @@ func (u unitYs) XY(i int) (float64, float64) {
 // that connects each point in the Line.
 func (h *Histogram) Plot(c draw.Canvas, p *plot.Plot) {
 	trX, trY := p.Transforms(&c)
+	trB, trW, base := trX, trY, c.Min.Y
+	if h.Horizontal {
+		trB, trW, base = trY, trX, c.Min.X
+	}
+	// pt returns the canvas point at the position b
+	// along the bins and the height w.
+	pt := func(b, w vg.Length) vg.Point {
+		if h.Horizontal {
+			return vg.Point{X: w, Y: b}
+		}
+		return vg.Point{X: b, Y: w}
+	}
 
-	for _, bin := range h.Bins {
-		ymin := c.Min.Y
-		ymax := c.Min.Y
-		if 0 != bin.Weight {
-			ymax = trY(bin.Weight)
+	var outline []vg.Point
+	for i, weight := range h.weights() {
+		bin := h.Bins[i]
+		bmin, bmax := trB(bin.Min), trB(bin.Max)
+		top := base
+		if 0 != weight {
+			top = trW(weight)
+		}
+		if h.Outline {
+			// The outline returns to the base
+			// between bins that are not adjacent.
+			gap := i == 0 || bin.Min != h.Bins[i-1].Max
+			if gap && i > 0 {
+				outline = append(outline, pt(trB(h.Bins[i-1].Max), base))
+			}
+			if gap {
+				outline = append(outline, pt(bmin, base))
+			}
+			outline = append(outline, pt(bmin, top), pt(bmax, top))
+			continue
 		}
-		xmin := trX(bin.Min)
-		xmax := trX(bin.Max)
 		pts := []vg.Point{
-			{xmin, ymin},
-			{xmax, ymin},
-			{xmax, ymax},
-			{xmin, ymax},
+			pt(bmin, base),
+			pt(bmax, base),
+			pt(bmax, top),
+			pt(bmin, top),
 		}
+		poly := c.ClipPolygonXY(pts)
 		if h.FillColor != nil {
-			c.FillPolygon(h.FillColor, c.ClipPolygonXY(pts))
+			c.FillPolygon(h.FillColor, poly)
 		}
-		pts = append(pts, vg.Point{X: xmin, Y: ymin})
+		c.FillPolygonHatch(h.Hatch, poly)
+		pts = append(pts, pts[0])
 		c.StrokeLines(h.LineStyle, c.ClipLinesXY(pts)...)
 	}
+	if len(outline) == 0 {
+		return
+	}
+	outline = append(outline, pt(trB(h.Bins[len(h.Bins)-1].Max), base))
+	poly := c.ClipPolygonXY(outline)
+	if h.FillColor != nil {
+		c.FillPolygon(h.FillColor, poly)
+	}
+	c.FillPolygonHatch(h.Hatch, poly)
+	c.StrokeLines(h.LineStyle, c.ClipLinesXY(outline)...)
+}
+
+// weights returns the heights of the bars of the
+// bins, accumulated if Cumulative is set.
+func (h *Histogram) weights() []float64 {
+	ws := make([]float64, len(h.Bins))
+	var sum float64
+	for i, bin := range h.Bins {
+		ws[i] = bin.Weight
+		if h.Cumulative {
+			sum += bin.Weight
+			ws[i] = sum
+		}
+	}
+	return ws
 }
 
 // DataRange returns the minimum and maximum X and Y values
@@ -113,21 +222,22 @@ func (h *Histogram) DataRange() (xmin, xmax, ymin, ymax float64) {
 	ymin = math.Inf(+1)
 	ymax = math.Inf(-1)
 	ylow := math.Inf(+1) // ylow will hold the smallest non-zero y value.
-	for _, bin := range h.Bins {
+	for i, weight := range h.weights() {
+		bin := h.Bins[i]
 		if bin.Max > xmax {
 			xmax = bin.Max
 		}
 		if bin.Min < xmin {
 			xmin = bin.Min
 		}
-		if bin.Weight > ymax {
-			ymax = bin.Weight
+		if weight > ymax {
+			ymax = weight
 		}
-		if bin.Weight < ymin {
-			ymin = bin.Weight
+		if weight < ymin {
+			ymin = weight
 		}
-		if bin.Weight != 0 && bin.Weight < ylow {
-			ylow = bin.Weight
+		if weight != 0 && weight < ylow {
+			ylow = weight
 		}
 	}
 	switch h.LogY {
@@ -139,18 +249,28 @@ func (h *Histogram) DataRange() (xmin, xmax, ymin, ymax float64) {
 	default:
 		ymin = 0
 	}
+	if h.Horizontal {
+		return ymin, ymax, xmin, xmax
+	}
 	return
 }
 
 // Normalize normalizes the histogram so that the
-// total area beneath it sums to a given value.
+// total area beneath it sums to a given value. The
+// weight of each bin is divided by the width of the
+// bin, so that histograms with bins of different
+// widths are normalized to a density.
 func (h *Histogram) Normalize(sum float64) {
 	mass := 0.0
 	for _, b := range h.Bins {
 		mass += b.Weight
 	}
-	for i := range h.Bins {
-		h.Bins[i].Weight *= sum / (h.Width * mass)
+	for i, b := range h.Bins {
+		w := h.Width
+		if w == 0 {
+			w = b.Max - b.Min
+		}
+		h.Bins[i].Weight *= sum / (w * mass)
 	}
 }
 
@@ -167,9 +287,11 @@ func (h *Histogram) Thumbnail(c *draw.Canvas) {
 		{xmax, ymax},
 		{xmin, ymax},
 	}
+	poly := c.ClipPolygonXY(pts)
 	if h.FillColor != nil {
-		c.FillPolygon(h.FillColor, c.ClipPolygonXY(pts))
+		c.FillPolygon(h.FillColor, poly)
 	}
+	c.FillPolygonHatch(h.Hatch, poly)
 	pts = append(pts, vg.Point{X: xmin, Y: ymin})
 	c.StrokeLines(h.LineStyle, c.ClipLinesXY(pts)...)
 }
@@ -17,6 +17,8 @@ import (
 	"github.com/gshk/plot/cmpimg"
 	"github.com/gshk/plot/plotter"
 	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/draw"
+	"github.com/gshk/plot/vg/recorder"
 )
 
 // An example of making a histogram.
@@ -143,3 +145,177 @@ func ExampleHistogram_logScaleY() {
 func TestHistogramLogScale(t *testing.T) {
 	cmpimg.CheckPlot(ExampleHistogram_logScaleY, t, "histogram_logy.png")
 }
+
+func TestHistogramHorizontal(t *testing.T) {
+	hist, err := plotter.NewHist(plotter.Values{1, 2, 2, 3, 3, 3}, 3)
+	if err != nil {
+		t.Fatalf("unexpected error from NewHist: %v", err)
+	}
+	hist.Horizontal = true
+	xmin, xmax, ymin, ymax := hist.DataRange()
+	if xmin != 0 || xmax != 3 || ymin != 1 || ymax != 3 {
+		t.Errorf("unexpected data range: got:%v %v %v %v want:0 3 1 3", xmin, xmax, ymin, ymax)
+	}
+}
+
+// ExampleHistogram_overlay draws the outlines of two
+// overlaid histograms sharing their bins, and their
+// cumulative distributions with weighted samples.
+func ExampleHistogram_overlay() {
+	rnd := rand.New(rand.NewSource(1))
+
+	a := make(plotter.Values, 1000)
+	b := make(plotter.XYs, 1000)
+	for i := range a {
+		a[i] = rnd.NormFloat64()
+		b[i].X = 1 + 0.5*rnd.NormFloat64()
+		b[i].Y = 0.5 // Each sample of b has half the weight of a sample of a.
+	}
+	edges, err := plotter.SharedEdges(plotter.FreedmanDiaconisRule, a, plotter.XValues{b})
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Overlaid histograms"
+	colors := []color.Color{color.RGBA{R: 196, A: 255}, color.RGBA{B: 196, A: 255}}
+	for _, cumulative := range []bool{false, true} {
+		ha, err := plotter.NewHistEdges(a, edges)
+		if err != nil {
+			log.Panic(err)
+		}
+		hb, err := plotter.NewHistogramEdges(b, edges)
+		if err != nil {
+			log.Panic(err)
+		}
+		for i, h := range []*plotter.Histogram{ha, hb} {
+			h.Outline = true
+			h.Cumulative = cumulative
+			h.FillColor = nil
+			h.Color = colors[i]
+			if cumulative {
+				h.Dashes = []vg.Length{vg.Points(4), vg.Points(2)}
+			}
+			p.Add(h)
+		}
+	}
+
+	err = p.Save(200, 200, "testdata/histogram_overlay.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestHistogramOverlay(t *testing.T) {
+	cmpimg.CheckPlot(ExampleHistogram_overlay, t, "histogram_overlay.png")
+}
+
+func TestBinRule(t *testing.T) {
+	vs := make(plotter.Values, 100)
+	for i := range vs {
+		vs[i] = float64(i)
+	}
+	for _, test := range []struct {
+		rule plotter.BinRule
+		want int
+	}{
+		{rule: plotter.SqrtRule, want: 10},
+		{rule: plotter.SturgesRule, want: 8},
+		// σ=29.01 and n^(-1/3)=0.2154, so the width is 21.81.
+		{rule: plotter.ScottRule, want: 5},
+		// IQR=49.5, so the width is 21.33.
+		{rule: plotter.FreedmanDiaconisRule, want: 5},
+	} {
+		if got := test.rule.Bins(vs); got != test.want {
+			t.Errorf("unexpected number of bins for rule %d: got:%d want:%d", test.rule, got, test.want)
+		}
+	}
+	if got := plotter.ScottRule.Bins(plotter.Values{1, 1, 1}); got != 1 {
+		t.Errorf("unexpected number of bins for constant values: got:%d want:1", got)
+	}
+}
+
+func TestHistogramEdges(t *testing.T) {
+	xys := plotter.XYs{{X: 1, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: 0.5}, {X: 10, Y: 1}, {X: 100, Y: 4}, {X: 1000, Y: 1}}
+	h, err := plotter.NewHistogramEdges(xys, plotter.LogEdges(1, 100, 2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h.Width != 0 {
+		t.Errorf("unexpected width for non-uniform bins: got:%v want:0", h.Width)
+	}
+	want := []plotter.HistogramBin{
+		{Min: 1, Max: 10, Weight: 3.5},
+		{Min: 10, Max: 100, Weight: 5},
+	}
+	for i, bin := range h.Bins {
+		if math.Abs(bin.Min-want[i].Min) > 1e-12 || math.Abs(bin.Max-want[i].Max) > 1e-12 || bin.Weight != want[i].Weight {
+			t.Errorf("unexpected bin %d: got:%+v want:%+v", i, bin, want[i])
+		}
+	}
+
+	h.Normalize(1)
+	var area float64
+	for _, bin := range h.Bins {
+		area += bin.Weight * (bin.Max - bin.Min)
+	}
+	if math.Abs(area-1) > 1e-12 {
+		t.Errorf("unexpected normalized area: got:%v want:1", area)
+	}
+
+	for _, edges := range [][]float64{nil, {1}, {1, 1}, {2, 1}, {0, math.NaN()}, {0, math.Inf(1)}} {
+		if _, err := plotter.NewHistogramEdges(xys, edges); err == nil {
+			t.Errorf("expected error for edges %v", edges)
+		}
+	}
+}
+
+func TestHistogramCumulative(t *testing.T) {
+	h, err := plotter.NewHistEdges(plotter.Values{0.5, 1.5, 1.5, 2.5}, []float64{0, 1, 2, 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	h.Cumulative = true
+	_, _, _, ymax := h.DataRange()
+	if ymax != 4 {
+		t.Errorf("unexpected cumulative maximum: got:%v want:4", ymax)
+	}
+}
+
+func TestHistogramOutline(t *testing.T) {
+	h, err := plotter.NewHistEdges(plotter.Values{0.5, 1.5, 1.5, 2.5}, []float64{0, 1, 2, 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	h.Outline = true
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(h)
+	p.HideAxes()
+	var rec recorder.Canvas
+	h.Plot(p.DataCanvas(draw.NewCanvas(&rec, 100, 100)), p)
+
+	var strokes, fills int
+	for _, a := range rec.Actions {
+		switch a := a.(type) {
+		case *recorder.Stroke:
+			strokes++
+			// The outline has a vertical and a horizontal
+			// segment for each bin, and a final vertical
+			// segment back to the base.
+			if got, want := len(a.Path), 8; got != want {
+				t.Errorf("unexpected number of outline path components: got:%d want:%d", got, want)
+			}
+		case *recorder.Fill:
+			fills++
+		}
+	}
+	if strokes != 1 || fills != 1 {
+		t.Errorf("unexpected outline actions: got:%d strokes %d fills want:1 stroke 1 fill", strokes, fills)
+	}
+}
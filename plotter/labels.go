@@ -6,6 +6,7 @@ package plotter
 
 import (
 	"errors"
+	"math"
 
 	"github.com/gshk/plot"
 	"github.com/gshk/plot/vg"
@@ -34,10 +35,58 @@ type Labels struct {
 	TextStyle []draw.TextStyle
 
 	// XOffset and YOffset are added directly to the final
-	// label X and Y location respectively.
+	// label X and Y location respectively. They are
+	// ignored by the AvoidOverlap placement.
 	XOffset, YOffset vg.Length
+
+	// Placement specifies how the labels are placed
+	// relative to their points.
+	Placement LabelPlacement
+
+	// Avoid holds the locations of data glyphs, in
+	// addition to the labeled points, that labels placed
+	// with AvoidOverlap must not cover.
+	Avoid XYs
+
+	// GlyphRadius is the radius of the glyphs at the
+	// labeled points and at the locations in Avoid,
+	// used by the AvoidOverlap placement.
+	GlyphRadius vg.Length
+
+	// MaxDistance is the greatest distance from its
+	// point at which a label is placed by AvoidOverlap.
+	MaxDistance vg.Length
+
+	// LeaderStyle is the style of the leader lines
+	// drawn from points to labels placed further than
+	// LeaderDistance from them by AvoidOverlap.
+	LeaderStyle draw.LineStyle
+
+	// LeaderDistance is the distance from its point
+	// beyond which a label has a leader line.
+	LeaderDistance vg.Length
 }
 
+// LabelPlacement specifies how Labels are placed.
+type LabelPlacement int
+
+const (
+	// FixedPlacement places each label at its point,
+	// moved by the offsets of the Labels, regardless
+	// of other labels.
+	FixedPlacement LabelPlacement = iota
+
+	// AvoidOverlap places each label in turn at the
+	// first of a sequence of candidate positions in
+	// rings of increasing distance around its point
+	// where it does not overlap the labels placed
+	// before it, the glyphs at the labeled points and
+	// at Avoid, or the edges of the data area. Labels
+	// with no such position within MaxDistance are
+	// not drawn and are reported by Dropped.
+	AvoidOverlap
+)
+
 // NewLabels returns a new Labels using the DefaultFont and
 // the DefaultFontSize.
 func NewLabels(d XYLabeller) (*Labels, error) {
@@ -66,14 +115,22 @@ func NewLabels(d XYLabeller) (*Labels, error) {
 	}
 
 	return &Labels{
-		XYs:       xys,
-		Labels:    strs,
-		TextStyle: styles,
+		XYs:            xys,
+		Labels:         strs,
+		TextStyle:      styles,
+		GlyphRadius:    DefaultGlyphStyle.Radius,
+		MaxDistance:    vg.Points(40),
+		LeaderStyle:    DefaultLineStyle,
+		LeaderDistance: vg.Points(8),
 	}, nil
 }
 
 // Plot implements the Plotter interface, drawing labels.
 func (l *Labels) Plot(c draw.Canvas, p *plot.Plot) {
+	if l.Placement == AvoidOverlap {
+		l.plotPlaced(c, p)
+		return
+	}
 	trX, trY := p.Transforms(&c)
 	for i, label := range l.Labels {
 		pt := vg.Point{X: trX(l.XYs[i].X), Y: trY(l.XYs[i].Y)}
@@ -86,6 +143,142 @@ func (l *Labels) Plot(c draw.Canvas, p *plot.Plot) {
 	}
 }
 
+// Dropped returns the indices of the labels that are not drawn by
+// the AvoidOverlap placement when the labels are plotted on the data
+// canvas c of the plot p, as returned by p.DataCanvas. It returns nil
+// for other placements.
+func (l *Labels) Dropped(c draw.Canvas, p *plot.Plot) []int {
+	if l.Placement != AvoidOverlap {
+		return nil
+	}
+	_, dropped := l.place(c, p)
+	return dropped
+}
+
+// placedLabel is the position of a label
+// placed by AvoidOverlap.
+type placedLabel struct {
+	// index is the index of the label.
+	index int
+
+	// pt is the location of the labeled point.
+	pt vg.Point
+
+	// at and sty are the location and style
+	// used to draw the label text.
+	at  vg.Point
+	sty draw.TextStyle
+
+	// rect is the bounds of the label text.
+	rect vg.Rectangle
+}
+
+// labelDirections are the directions of the candidate positions
+// of a label from its point, in order of preference.
+var labelDirections = []vg.Point{
+	{X: 1, Y: 1}, {X: -1, Y: 1}, {X: 1, Y: -1}, {X: -1, Y: -1},
+	{X: 1}, {X: -1}, {Y: 1}, {Y: -1},
+}
+
+// place returns the placements of the labels drawn on the
+// data canvas c by AvoidOverlap, and the indices of the labels
+// that could not be placed.
+func (l *Labels) place(c draw.Canvas, p *plot.Plot) (placed []placedLabel, dropped []int) {
+	trX, trY := p.Transforms(&c)
+
+	glyph := vg.Point{X: l.GlyphRadius, Y: l.GlyphRadius}
+	var obstacles []vg.Rectangle
+	for _, xys := range []XYs{l.XYs, l.Avoid} {
+		for _, xy := range xys {
+			pt := vg.Point{X: trX(xy.X), Y: trY(xy.Y)}
+			if c.Contains(pt) {
+				obstacles = append(obstacles, vg.Rectangle{Min: pt.Sub(glyph), Max: pt.Add(glyph)})
+			}
+		}
+	}
+
+	gap := vg.Points(1)
+	for i, label := range l.Labels {
+		pt := vg.Point{X: trX(l.XYs[i].X), Y: trY(l.XYs[i].Y)}
+		if !c.Contains(pt) || label == "" {
+			continue
+		}
+		sty := l.TextStyle[i]
+		step := sty.Height(label) / 2
+		if step <= 0 {
+			step = vg.Points(1)
+		}
+
+		var ok bool
+	search:
+		for r := l.GlyphRadius + gap; r <= l.MaxDistance; r += step {
+			for _, d := range labelDirections {
+				cand := placedLabel{index: i, pt: pt, sty: sty}
+				cand.sty.XAlign = draw.XAlignment(-0.5 + 0.5*float64(d.X))
+				cand.sty.YAlign = draw.YAlignment(-0.5 + 0.5*float64(d.Y))
+				cand.at = pt.Add(d.Scale(r))
+				cand.rect = cand.sty.Rectangle(label)
+				cand.rect.Min = cand.rect.Min.Add(cand.at)
+				cand.rect.Max = cand.rect.Max.Add(cand.at)
+				if !labelFits(c, cand.rect, placed, obstacles) {
+					continue
+				}
+				placed = append(placed, cand)
+				ok = true
+				break search
+			}
+		}
+		if !ok {
+			dropped = append(dropped, i)
+		}
+	}
+	return placed, dropped
+}
+
+// labelFits returns whether the rectangle r lies within the
+// canvas c without overlapping any placed label or obstacle.
+func labelFits(c draw.Canvas, r vg.Rectangle, placed []placedLabel, obstacles []vg.Rectangle) bool {
+	if !c.Contains(r.Min) || !c.Contains(r.Max) {
+		return false
+	}
+	for _, o := range placed {
+		if overlaps(r, o.rect) {
+			return false
+		}
+	}
+	for _, o := range obstacles {
+		if overlaps(r, o) {
+			return false
+		}
+	}
+	return true
+}
+
+// overlaps returns whether the rectangles a and b overlap.
+func overlaps(a, b vg.Rectangle) bool {
+	return a.Min.X < b.Max.X && b.Min.X < a.Max.X && a.Min.Y < b.Max.Y && b.Min.Y < a.Max.Y
+}
+
+// plotPlaced draws the labels using the AvoidOverlap placement.
+func (l *Labels) plotPlaced(c draw.Canvas, p *plot.Plot) {
+	placed, _ := l.place(c, p)
+	for _, pl := range placed {
+		// The leader line runs from the edge of the glyph
+		// at the point to the nearest point of the label.
+		near := vg.Point{
+			X: vg.Length(math.Max(float64(pl.rect.Min.X), math.Min(float64(pl.pt.X), float64(pl.rect.Max.X)))),
+			Y: vg.Length(math.Max(float64(pl.rect.Min.Y), math.Min(float64(pl.pt.Y), float64(pl.rect.Max.Y)))),
+		}
+		d := near.Sub(pl.pt)
+		dist := vg.Length(math.Hypot(float64(d.X), float64(d.Y)))
+		if dist > l.LeaderDistance && dist > l.GlyphRadius {
+			from := pl.pt.Add(d.Scale(l.GlyphRadius / dist))
+			c.StrokeLine2(l.LeaderStyle, from.X, from.Y, near.X, near.Y)
+		}
+		c.FillText(pl.sty, pl.at, l.Labels[pl.index])
+	}
+}
+
 // DataRange returns the minimum and maximum X and Y values
 func (l *Labels) DataRange() (xmin, xmax, ymin, ymax float64) {
 	return XYRange(l)
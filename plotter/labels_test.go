@@ -0,0 +1,115 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter_test
+
+import (
+	"fmt"
+	"log"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/cmpimg"
+	"github.com/gshk/plot/plotter"
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/draw"
+	"github.com/gshk/plot/vg/recorder"
+)
+
+// ExampleLabels_avoidOverlap draws a dense scatter plot with
+// labels placed so that they do not overlap each other or the
+// data glyphs.
+func ExampleLabels_avoidOverlap() {
+	rnd := rand.New(rand.NewSource(1))
+	const n = 30
+	xyl := plotter.XYLabels{
+		XYs:    make(plotter.XYs, n),
+		Labels: make([]string, n),
+	}
+	for i := range xyl.XYs {
+		xyl.XYs[i].X = rnd.NormFloat64()
+		xyl.XYs[i].Y = rnd.NormFloat64()
+		xyl.Labels[i] = fmt.Sprintf("p%d", i)
+	}
+
+	s, err := plotter.NewScatter(xyl)
+	if err != nil {
+		log.Panic(err)
+	}
+	s.Radius = vg.Points(3)
+	l, err := plotter.NewLabels(xyl)
+	if err != nil {
+		log.Panic(err)
+	}
+	l.Placement = plotter.AvoidOverlap
+	l.GlyphRadius = s.Radius
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Add(s, l)
+
+	err = p.Save(300, 300, "testdata/labels_avoid.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestLabelsAvoidOverlapExample(t *testing.T) {
+	cmpimg.CheckPlot(ExampleLabels_avoidOverlap, t, "labels_avoid.png")
+}
+
+func TestLabelsAvoidOverlap(t *testing.T) {
+	const n = 20
+	xyl := plotter.XYLabels{
+		XYs:    make(plotter.XYs, n),
+		Labels: make([]string, n),
+	}
+	for i := range xyl.Labels {
+		// All points are at the origin, so only a few
+		// labels fit within the maximum distance.
+		xyl.Labels[i] = fmt.Sprintf("label %d", i)
+	}
+	l, err := plotter.NewLabels(xyl)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	l.Placement = plotter.AvoidOverlap
+	l.MaxDistance = vg.Points(20)
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = -1, 1
+	p.Y.Min, p.Y.Max = -1, 1
+	p.Add(l)
+
+	var c recorder.Canvas
+	dc := draw.NewCanvas(&c, 300, 300)
+	l.Plot(dc, p)
+
+	dropped := l.Dropped(dc, p)
+	var drawn int
+	for _, a := range c.Actions {
+		if _, ok := a.(*recorder.FillString); ok {
+			drawn++
+		}
+	}
+	if drawn == 0 || len(dropped) == 0 {
+		t.Fatalf("unexpected placement: got %d drawn and %d dropped labels", drawn, len(dropped))
+	}
+	if drawn+len(dropped) != n {
+		t.Errorf("unexpected number of labels: drawn:%d dropped:%d want total:%d", drawn, len(dropped), n)
+	}
+	for i, d := range dropped {
+		if i > 0 && d <= dropped[i-1] {
+			t.Errorf("dropped labels not in order: %v", dropped)
+			break
+		}
+	}
+}
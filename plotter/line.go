@@ -6,7 +6,6 @@ package plotter
 
 import (
 	"image/color"
-	"math"
 
 	"github.com/gshk/plot"
 	"github.com/gshk/plot/vg"
@@ -46,6 +45,29 @@ type Line struct {
 	// FillColor is the color to fill the area below the plot.
 	// Use nil to disable the filling. This is the default.
 	FillColor color.Color
+
+	// Gradient, if not empty, holds the stops of a
+	// linear gradient that fills the area below the
+	// plot in place of FillColor, from offset 0 at the
+	// bottom of the plot to offset 1 at its top.
+	Gradient []vg.GradientStop
+
+	// Hatch is the hatch pattern drawn over the area
+	// below the plot. The zero Hatch draws nothing.
+	Hatch vg.Hatch
+
+	// Downsampling specifies how the points of the line
+	// are reduced for the resolution of the output when
+	// the line is drawn. Downsampling is only applied to
+	// lines with no StepStyle whose X values are in
+	// non-decreasing order.
+	Downsampling Downsampling
+
+	// DownsamplingDPI is the resolution in dots per inch
+	// used for Downsampling. If DownsamplingDPI is zero,
+	// the resolution of the canvas is used if it is
+	// known, and DefaultDownsamplingDPI otherwise.
+	DownsamplingDPI float64
 }
 
 // NewLine returns a Line that uses the default line style and
@@ -62,6 +84,8 @@ func NewLine(xys XYer) (*Line, error) {
 }
 
 // Plot draws the Line, implementing the plot.Plotter interface.
+// Points with a NaN X or Y value are not drawn and break the
+// line and its fill into separate parts.
 func (pts *Line) Plot(c draw.Canvas, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&c)
 	ps := make([]vg.Point, len(pts.XYs))
@@ -70,90 +94,126 @@ func (pts *Line) Plot(c draw.Canvas, plt *plot.Plot) {
 		ps[i].X = trX(p.X)
 		ps[i].Y = trY(p.Y)
 	}
+	if pts.StepStyle == NoStep {
+		ps = downsample(pts.Downsampling, ps, 0, columnWidth(c, pts.DownsamplingDPI))
+	}
+	runs := splitGaps(ps)
 
-	if pts.FillColor != nil && len(ps) > 0 {
+	if pts.FillColor != nil || len(pts.Gradient) != 0 || pts.Hatch.Pattern != vg.NoHatch {
 		minY := trY(plt.Y.Min)
-		fillPoly := []vg.Point{{X: ps[0].X, Y: minY}}
+		for _, run := range runs {
+			if len(run) > 1 {
+				pts.fill(c, run, minY)
+			}
+		}
+	}
+
+	if pts.LineStyle.Width != 0 {
+		for _, run := range runs {
+			pts.stroke(c, run)
+		}
+	}
+}
+
+// splitGaps returns the runs of consecutive points of ps
+// that are separated by points with a NaN coordinate.
+func splitGaps(ps []vg.Point) [][]vg.Point {
+	var runs [][]vg.Point
+	start := 0
+	for i, p := range ps {
+		if isNaNPoint(p) {
+			if i > start {
+				runs = append(runs, ps[start:i])
+			}
+			start = i + 1
+		}
+	}
+	if start < len(ps) {
+		runs = append(runs, ps[start:])
+	}
+	return runs
+}
+
+// fill fills the area between the points of ps and minY.
+func (pts *Line) fill(c draw.Canvas, ps []vg.Point, minY vg.Length) {
+	fillPoly := []vg.Point{{X: ps[0].X, Y: minY}}
+	switch pts.StepStyle {
+	case PreStep:
+		fillPoly = append(fillPoly, ps[1:]...)
+	case PostStep:
+		fillPoly = append(fillPoly, ps[:len(ps)-1]...)
+	default:
+		fillPoly = append(fillPoly, ps...)
+	}
+	fillPoly = append(fillPoly, vg.Point{X: ps[len(ps)-1].X, Y: minY})
+	fillPoly = c.ClipPolygonXY(fillPoly)
+	if len(fillPoly) == 0 {
+		return
+	}
+	var pa vg.Path
+	prev := fillPoly[0]
+	pa.Move(prev)
+	for _, pt := range fillPoly[1:] {
 		switch pts.StepStyle {
+		case NoStep:
+			pa.Line(pt)
 		case PreStep:
-			fillPoly = append(fillPoly, ps[1:]...)
+			pa.Line(vg.Point{X: prev.X, Y: pt.Y})
+			pa.Line(pt)
+		case MidStep:
+			pa.Line(vg.Point{X: (prev.X + pt.X) / 2, Y: prev.Y})
+			pa.Line(vg.Point{X: (prev.X + pt.X) / 2, Y: pt.Y})
+			pa.Line(pt)
 		case PostStep:
-			fillPoly = append(fillPoly, ps[:len(ps)-1]...)
-		default:
-			fillPoly = append(fillPoly, ps...)
-		}
-		fillPoly = append(fillPoly, vg.Point{X: ps[len(ps)-1].X, Y: minY})
-		fillPoly = c.ClipPolygonXY(fillPoly)
-		if len(fillPoly) > 0 {
-			c.SetColor(pts.FillColor)
-			var pa vg.Path
-			prev := fillPoly[0]
-			pa.Move(prev)
-			for _, pt := range fillPoly[1:] {
-				if (math.IsNaN(float64(pt.X)) || math.IsNaN(float64(pt.Y))) &&
-					!(math.IsNaN(float64(prev.X)) || math.IsNaN(float64(prev.Y))) {
-					pa.Line(vg.Point{X: prev.X, Y: minY})
-					pa.Close()
-					prev = pt
-					continue
-				}
-				if math.IsNaN(float64(prev.X)) || math.IsNaN(float64(prev.Y)) {
-					pa.Move(vg.Point{X: pt.X, Y: minY})
-					pa.Line(pt)
-					prev = pt
-					continue
-				}
-				switch pts.StepStyle {
-				case NoStep:
-					pa.Line(pt)
-				case PreStep:
-					pa.Line(vg.Point{X: prev.X, Y: pt.Y})
-					pa.Line(pt)
-				case MidStep:
-					pa.Line(vg.Point{X: (prev.X + pt.X) / 2, Y: prev.Y})
-					pa.Line(vg.Point{X: (prev.X + pt.X) / 2, Y: pt.Y})
-					pa.Line(pt)
-				case PostStep:
-					pa.Line(vg.Point{X: pt.X, Y: prev.Y})
-					pa.Line(pt)
-				}
-				prev = pt
-			}
-			pa.Close()
-			c.Fill(pa)
+			pa.Line(vg.Point{X: pt.X, Y: prev.Y})
+			pa.Line(pt)
 		}
+		prev = pt
+	}
+	pa.Close()
+	switch {
+	case len(pts.Gradient) != 0:
+		c.FillGradient(vg.Gradient{
+			Kind:  vg.LinearGradient,
+			Start: vg.Point{X: c.Min.X, Y: minY},
+			End:   vg.Point{X: c.Min.X, Y: c.Max.Y},
+			Stops: pts.Gradient,
+		}, pa)
+	case pts.FillColor != nil:
+		c.SetColor(pts.FillColor)
+		c.Fill(pa)
 	}
+	c.FillHatch(pts.Hatch, pa)
+}
 
+// stroke strokes the line through the points of ps.
+func (pts *Line) stroke(c draw.Canvas, ps []vg.Point) {
 	lines := c.ClipLinesXY(ps)
-	if pts.LineStyle.Width != 0 && len(lines) != 0 {
-		c.SetLineStyle(pts.LineStyle)
-		for _, l := range lines {
-			if len(l) == 0 {
-				continue
-			}
-			var p vg.Path
-			prev := l[0]
-			p.Move(prev)
-			for _, pt := range l[1:] {
-				if math.IsNaN(float64(pt.X)) || math.IsNaN(float64(pt.Y)) || math.IsNaN(float64(prev.X)) || math.IsNaN(float64(prev.Y)) {
-					p.Move(pt)
-					prev = pt
-					continue
-				}
-				switch pts.StepStyle {
-				case PreStep:
-					p.Line(vg.Point{X: prev.X, Y: pt.Y})
-				case MidStep:
-					p.Line(vg.Point{X: (prev.X + pt.X) / 2, Y: prev.Y})
-					p.Line(vg.Point{X: (prev.X + pt.X) / 2, Y: pt.Y})
-				case PostStep:
-					p.Line(vg.Point{X: pt.X, Y: prev.Y})
-				}
-				p.Line(pt)
-				prev = pt
+	if len(lines) == 0 {
+		return
+	}
+	c.SetLineStyle(pts.LineStyle)
+	for _, l := range lines {
+		if len(l) == 0 {
+			continue
+		}
+		var p vg.Path
+		prev := l[0]
+		p.Move(prev)
+		for _, pt := range l[1:] {
+			switch pts.StepStyle {
+			case PreStep:
+				p.Line(vg.Point{X: prev.X, Y: pt.Y})
+			case MidStep:
+				p.Line(vg.Point{X: (prev.X + pt.X) / 2, Y: prev.Y})
+				p.Line(vg.Point{X: (prev.X + pt.X) / 2, Y: pt.Y})
+			case PostStep:
+				p.Line(vg.Point{X: pt.X, Y: prev.Y})
 			}
-			c.Stroke(p)
+			p.Line(pt)
+			prev = pt
 		}
+		c.Stroke(p)
 	}
 }
 
@@ -165,7 +225,7 @@ func (pts *Line) DataRange() (xmin, xmax, ymin, ymax float64) {
 
 // Thumbnail returns the thumbnail for the Line, implementing the plot.Thumbnailer interface.
 func (pts *Line) Thumbnail(c *draw.Canvas) {
-	if pts.FillColor != nil {
+	if pts.FillColor != nil || len(pts.Gradient) != 0 || pts.Hatch.Pattern != vg.NoHatch {
 		var topY vg.Length
 		if pts.LineStyle.Width == 0 {
 			topY = c.Max.Y
@@ -179,7 +239,18 @@ func (pts *Line) Thumbnail(c *draw.Canvas) {
 			{X: c.Max.X, Y: c.Min.Y},
 		}
 		poly := c.ClipPolygonY(points)
-		c.FillPolygon(pts.FillColor, poly)
+		switch {
+		case len(pts.Gradient) != 0:
+			c.FillPolygonGradient(vg.Gradient{
+				Kind:  vg.LinearGradient,
+				Start: points[0],
+				End:   points[1],
+				Stops: pts.Gradient,
+			}, poly)
+		case pts.FillColor != nil:
+			c.FillPolygon(pts.FillColor, poly)
+		}
+		c.FillPolygonHatch(pts.Hatch, poly)
 	}
 
 	if pts.LineStyle.Width != 0 {
@@ -0,0 +1,94 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter_test
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/plotter"
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/draw"
+	"github.com/gshk/plot/vg/recorder"
+)
+
+func TestLineGaps(t *testing.T) {
+	nan := math.NaN()
+	xys := plotter.XYs{
+		{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 0},
+		{X: 3, Y: nan},
+		{X: 4, Y: 1}, {X: 5, Y: 2},
+		{X: nan, Y: 0},
+		{X: 7, Y: 1},
+	}
+	for _, step := range []plotter.StepKind{plotter.NoStep, plotter.PreStep, plotter.MidStep, plotter.PostStep} {
+		l, err := plotter.NewLine(xys)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		l.StepStyle = step
+		l.FillColor = color.Gray{Y: 200}
+
+		xmin, xmax, ymin, ymax := l.DataRange()
+		if xmin != 0 || xmax != 7 || ymin != 0 || ymax != 2 {
+			t.Errorf("unexpected data range: got:%v %v %v %v want:0 7 0 2", xmin, xmax, ymin, ymax)
+		}
+
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.Add(l)
+
+		var c recorder.Canvas
+		l.Plot(draw.NewCanvas(&c, 300, 200), p)
+
+		var strokes, fills int
+		for _, a := range c.Actions {
+			var path vg.Path
+			switch a := a.(type) {
+			case *recorder.Stroke:
+				strokes++
+				path = a.Path
+			case *recorder.Fill:
+				fills++
+				path = a.Path
+			default:
+				continue
+			}
+			for _, comp := range path {
+				if math.IsNaN(float64(comp.Pos.X)) || math.IsNaN(float64(comp.Pos.Y)) {
+					t.Errorf("unexpected NaN in path for step %d: %v", step, path)
+					break
+				}
+			}
+		}
+		// The single point after the last gap
+		// is neither stroked nor filled.
+		if strokes != 2 || fills != 2 {
+			t.Errorf("unexpected number of parts for step %d: got %d strokes and %d fills want 2 and 2", step, strokes, fills)
+		}
+	}
+}
+
+func TestCheckFinite(t *testing.T) {
+	if err := plotter.CheckFloats(0, math.NaN()); err != nil {
+		t.Errorf("unexpected error from CheckFloats for NaN: %v", err)
+	}
+	for _, test := range []struct {
+		fs   []float64
+		want error
+	}{
+		{fs: []float64{0, 1}, want: nil},
+		{fs: []float64{0, math.NaN()}, want: plotter.ErrNaN},
+		{fs: []float64{math.Inf(-1), 0}, want: plotter.ErrInfinity},
+	} {
+		if err := plotter.CheckFinite(test.fs...); err != test.want {
+			t.Errorf("unexpected error from CheckFinite(%v): got:%v want:%v", test.fs, err, test.want)
+		}
+	}
+}
@@ -9,9 +9,12 @@
 // the data area of a plot. This package provides some standard data
 // styles such as lines, scatter plots, box plots, labels, and more.
 //
-// New* functions return an error if the data contains Inf, NaN, or is
-// empty. Some of the New* functions return other plotter-specific errors
-// too.
+// New* functions return an error if the data contains Inf or is empty.
+// Some of the New* functions return other plotter-specific errors too.
+// NaN values are accepted by the XY plotters Line and Scatter as markers
+// of missing data: lines are broken at them and scatter points are not
+// drawn. Data that must not contain NaN can be validated with
+// CheckFinite.
 package plotter // import "github.com/gshk/plot/plotter"
 
 import (
@@ -51,13 +54,14 @@ type Valuer interface {
 	Value(int) float64
 }
 
-// Range returns the minimum and maximum values.
+// Range returns the minimum and maximum values,
+// ignoring values that are NaN or infinite.
 func Range(vs Valuer) (min, max float64) {
 	min = math.Inf(1)
 	max = math.Inf(-1)
 	for i := 0; i < vs.Len(); i++ {
 		v := vs.Value(i)
-		if !math.IsNaN(v) {
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
 			min = math.Min(min, v)
 			max = math.Max(max, v)
 		}
@@ -70,10 +74,12 @@ type Values []float64
 
 var (
 	ErrInfinity = errors.New("Infinite data point")
+	ErrNaN      = errors.New("NaN data point")
 	ErrNoData   = errors.New("No data points")
 )
 
-// CheckFloats returns an error if any of the arguments are NaN or Infinity.
+// CheckFloats returns an error if any of the arguments are Infinity.
+// NaN values are allowed since they mark missing data.
 func CheckFloats(fs ...float64) error {
 	for _, f := range fs {
 		switch {
@@ -84,9 +90,24 @@ func CheckFloats(fs ...float64) error {
 	return nil
 }
 
+// CheckFinite returns an error if any of the arguments are NaN or
+// Infinity. It can be used to validate data that must not have
+// missing values.
+func CheckFinite(fs ...float64) error {
+	for _, f := range fs {
+		switch {
+		case math.IsNaN(f):
+			return ErrNaN
+		case math.IsInf(f, 0):
+			return ErrInfinity
+		}
+	}
+	return nil
+}
+
 // CopyValues returns a Values that is a copy of the values
 // from a Valuer, or an error if there are no values, or if one of
-// the copied values is a NaN or Infinity.
+// the copied values is an Infinity.
 func CopyValues(vs Valuer) (Values, error) {
 	if vs.Len() == 0 {
 		return nil, ErrNoData
@@ -119,10 +140,19 @@ type XYer interface {
 }
 
 // XYRange returns the minimum and maximum
-// x and y values.
+// x and y values, ignoring points with an x
+// or y value that is NaN or infinite.
 func XYRange(xys XYer) (xmin, xmax, ymin, ymax float64) {
-	xmin, xmax = Range(XValues{xys})
-	ymin, ymax = Range(YValues{xys})
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	ymin, ymax = math.Inf(1), math.Inf(-1)
+	for i := 0; i < xys.Len(); i++ {
+		x, y := xys.XY(i)
+		if math.IsNaN(x) || math.IsNaN(y) || math.IsInf(x, 0) || math.IsInf(y, 0) {
+			continue
+		}
+		xmin, xmax = math.Min(xmin, x), math.Max(xmax, x)
+		ymin, ymax = math.Min(ymin, y), math.Max(ymax, y)
+	}
 	return
 }
 
@@ -133,8 +163,7 @@ type XYs []XY
 type XY struct{ X, Y float64 }
 
 // CopyXYs returns an XYs that is a copy of the x and y values from
-// an XYer, or an error if one of the data points contains a NaN or
-// Infinity.
+// an XYer, or an error if one of the data points contains an Infinity.
 func CopyXYs(data XYer) (XYs, error) {
 	cpy := make(XYs, data.Len())
 	for i := range cpy {
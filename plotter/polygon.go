@@ -26,14 +26,22 @@ type Polygon struct {
 
 	// Color is the fill color of the polygon.
 	Color color.Color
+
+	// FillRule is the rule deciding which points
+	// are inside of the rings of the polygon.
+	FillRule vg.FillRule
+
+	// Hatch is the hatch pattern drawn over the
+	// fill of the polygon. The zero Hatch draws
+	// nothing.
+	Hatch vg.Hatch
 }
 
 // NewPolygon returns a polygon that uses the default line style and
 // no fill color, where xys are the rings of the polygon.
-// Different backends may render overlapping rings and self-intersections
-// differently, but all built-in backends treat inner rings
-// with the opposite winding order from the outer ring as
-// holes.
+// With the default vg.NonZero fill rule inner rings with the opposite
+// winding order from the outer ring are holes, and with vg.EvenOdd
+// all inner rings are holes.
 func NewPolygon(xys ...XYer) (*Polygon, error) {
 	data := make([]XYs, len(xys))
 	for i, d := range xys {
@@ -63,20 +71,30 @@ func (pts *Polygon) Plot(c draw.Canvas, plt *plot.Plot) {
 		}
 		ps[i] = c.ClipPolygonXY(ps[i])
 	}
-	if pts.Color != nil && len(ps) > 0 {
-		c.SetColor(pts.Color)
-		var pa vg.Path
-		for _, ring := range ps {
-			if len(ring) == 0 {
-				continue
-			}
-			pa.Move(ring[0])
-			for _, p := range ring {
-				pa.Line(p)
-			}
-			pa.Close()
+	var pa vg.Path
+	for _, ring := range ps {
+		if len(ring) == 0 {
+			continue
+		}
+		pa.Move(ring[0])
+		for _, p := range ring {
+			pa.Line(p)
+		}
+		pa.Close()
+	}
+	if len(pa) > 0 {
+		if pts.FillRule != vg.NonZero {
+			c.Push()
+			c.SetFillRule(pts.FillRule)
+		}
+		if pts.Color != nil {
+			c.SetColor(pts.Color)
+			c.Fill(pa)
+		}
+		c.FillHatch(pts.Hatch, pa)
+		if pts.FillRule != vg.NonZero {
+			c.Pop()
 		}
-		c.Fill(pa)
 	}
 
 	for _, ring := range ps {
@@ -109,7 +127,7 @@ func (pts *Polygon) DataRange() (xmin, xmax, ymin, ymax float64) {
 // Thumbnail creates the thumbnail for the Polygon,
 // implementing the plot.Thumbnailer interface.
 func (pts *Polygon) Thumbnail(c *draw.Canvas) {
-	if pts.Color != nil {
+	if pts.Color != nil || pts.Hatch.Pattern != vg.NoHatch {
 		points := []vg.Point{
 			{X: c.Min.X, Y: c.Min.Y},
 			{X: c.Min.X, Y: c.Max.Y},
@@ -117,7 +135,10 @@ func (pts *Polygon) Thumbnail(c *draw.Canvas) {
 			{X: c.Max.X, Y: c.Min.Y},
 		}
 		poly := c.ClipPolygonY(points)
-		c.FillPolygon(pts.Color, poly)
+		if pts.Color != nil {
+			c.FillPolygon(pts.Color, poly)
+		}
+		c.FillPolygonHatch(pts.Hatch, poly)
 
 		points = append(points, vg.Point{X: c.Min.X, Y: c.Min.Y})
 		c.StrokeLines(pts.LineStyle, points)
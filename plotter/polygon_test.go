@@ -89,6 +89,52 @@ func TestPolygon_holes(t *testing.T) {
 	cmpimg.CheckPlot(ExamplePolygon_holes, t, "polygon_holes.png", "polygon_holes.svg", "polygon_holes.pdf", "polygon_holes.eps")
 }
 
+// ExamplePolygon_evenOdd draws the polygon of ExamplePolygon_holes
+// with the even-odd fill rule, with which all of the built-in vg
+// backends treat both inner rings as holes.
+// The output of this example is at
+// https://github.com/gonum/plot/blob/master/plotter/testdata/polygon_evenOdd_golden.png,
+// https://github.com/gonum/plot/blob/master/plotter/testdata/polygon_evenOdd_golden.svg,
+// https://github.com/gonum/plot/blob/master/plotter/testdata/polygon_evenOdd_golden.pdf, and
+// https://github.com/gonum/plot/blob/master/plotter/testdata/polygon_evenOdd_golden.eps.
+func ExamplePolygon_evenOdd() {
+	outer := plotter.XYs{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 4}}
+	inner1 := plotter.XYs{{X: 0.5, Y: 0.5}, {X: 1.5, Y: 0.5}, {X: 1.5, Y: 1.5}, {X: 0.5, Y: 1.5}}
+	inner2 := plotter.XYs{{X: 3.5, Y: 2.5}, {X: 2.5, Y: 2.5}, {X: 2.5, Y: 3.5}, {X: 3.5, Y: 3.5}}
+
+	poly, err := plotter.NewPolygon(outer, inner1, inner2)
+	if err != nil {
+		log.Panic(err)
+	}
+	poly.Color = color.NRGBA{B: 255, A: 255}
+	poly.FillRule = vg.EvenOdd
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Even-odd fill rule"
+	p.X.Label.Text = "X"
+	p.Y.Label.Text = "Y"
+	p.Add(poly)
+
+	for _, name := range []string{
+		"testdata/polygon_evenOdd.png",
+		"testdata/polygon_evenOdd.svg",
+		"testdata/polygon_evenOdd.pdf",
+		"testdata/polygon_evenOdd.eps",
+	} {
+		err = p.Save(100, 100, name)
+		if err != nil {
+			log.Panic(err)
+		}
+	}
+}
+
+func TestPolygon_evenOdd(t *testing.T) {
+	cmpimg.CheckPlot(ExamplePolygon_evenOdd, t, "polygon_evenOdd.png", "polygon_evenOdd.svg", "polygon_evenOdd.pdf", "polygon_evenOdd.eps")
+}
+
 // ExamplePolygon_hexagons creates a heat map with hexagon shapes.
 // The output of this example is at
 // https://github.com/gonum/plot/blob/master/plotter/testdata/polygon_hexagons_golden.png.
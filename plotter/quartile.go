@@ -69,7 +69,7 @@ type QuartPlot struct {
 func NewQuartPlot(loc float64, values Valuer) (*QuartPlot, error) {
 	b := new(QuartPlot)
 	var err error
-	if b.fiveStatPlot, err = newFiveStat(0, loc, values); err != nil {
+	if b.fiveStatPlot, err = newFiveStat(loc, values, IQRWhiskers(1.5)); err != nil {
 		return nil, err
 	}
 
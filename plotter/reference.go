@@ -0,0 +1,277 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/draw"
+)
+
+// RefLine implements the Plotter interface, drawing a reference line
+// across the full extent of the data area at a value of the X or Y
+// axis, such as a threshold or the time of an event.
+type RefLine struct {
+	// Value is the location of the line along
+	// the X axis, or along the Y axis if the line
+	// is Horizontal.
+	Value float64
+
+	// Horizontal specifies that the line is drawn
+	// parallel to the X axis at the Y location Value.
+	Horizontal bool
+
+	// LineStyle is the style of the line.
+	draw.LineStyle
+
+	// Label is the text drawn along the line, above
+	// it or to its left where there is room in the
+	// data area. Labels of vertical lines are rotated
+	// to run up the line.
+	Label string
+
+	// TextStyle is the style of the label. Its
+	// alignment and rotation are set according to
+	// the direction of the line and LabelPosition.
+	TextStyle draw.TextStyle
+
+	// LabelPosition is the position of the label
+	// along the line as a fraction of the data area,
+	// from the left or bottom end at 0 to the right
+	// or top end at 1. The label is aligned so that
+	// it stays within the data area.
+	LabelPosition float64
+
+	// ExtendRange specifies that DataRange includes
+	// Value, so that the line is always visible. By
+	// default a RefLine does not affect the range of
+	// its axis.
+	ExtendRange bool
+}
+
+// NewRefLine returns a RefLine at the given value, drawn
+// horizontally if horizontal is true, using the default
+// line style and font with the label at the end of the
+// line.
+func NewRefLine(value float64, horizontal bool) (*RefLine, error) {
+	if err := CheckFinite(value); err != nil {
+		return nil, err
+	}
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	sty := DefaultLineStyle
+	sty.Dashes = []vg.Length{vg.Points(4), vg.Points(2)}
+	return &RefLine{
+		Value:         value,
+		Horizontal:    horizontal,
+		LineStyle:     sty,
+		TextStyle:     draw.TextStyle{Font: fnt},
+		LabelPosition: 1,
+	}, nil
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (r *RefLine) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	var from, to vg.Point
+	if r.Horizontal {
+		y := trY(r.Value)
+		if !c.ContainsY(y) {
+			return
+		}
+		from, to = vg.Point{X: c.Min.X, Y: y}, vg.Point{X: c.Max.X, Y: y}
+	} else {
+		x := trX(r.Value)
+		if !c.ContainsX(x) {
+			return
+		}
+		from, to = vg.Point{X: x, Y: c.Min.Y}, vg.Point{X: x, Y: c.Max.Y}
+	}
+	c.StrokeLine2(r.LineStyle, from.X, from.Y, to.X, to.Y)
+
+	if r.Label == "" {
+		return
+	}
+	// The label is drawn above or to the left of the
+	// line unless that would place it outside the data
+	// area.
+	sty := refLabelStyle(r.TextStyle, r.Horizontal, r.LabelPosition)
+	sty.YAlign = draw.YBottom
+	h := sty.Height(r.Label)
+	if (r.Horizontal && from.Y+h > c.Max.Y) || (!r.Horizontal && from.X-h < c.Min.X) {
+		sty.YAlign = draw.YTop
+	}
+	pt := from.Add(to.Sub(from).Scale(vg.Length(r.LabelPosition)))
+	c.FillText(sty, pt, r.Label)
+}
+
+// refLabelStyle returns sty rotated to run along a reference line
+// or span and aligned to remain within the data area at the given
+// position along it.
+func refLabelStyle(sty draw.TextStyle, horizontal bool, pos float64) draw.TextStyle {
+	sty.XAlign = draw.XAlignment(-pos)
+	if !horizontal {
+		sty.Rotation = math.Pi / 2
+	}
+	return sty
+}
+
+// DataRange implements the DataRange method of the plot.DataRanger
+// interface. The returned range is empty unless ExtendRange is set.
+func (r *RefLine) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return refRange(r.Value, r.Value, r.Horizontal, r.ExtendRange)
+}
+
+// refRange returns the data range of a reference line or span
+// between min and max on the Y axis, if horizontal, or the X axis.
+// If extend is false, the returned range is empty.
+func refRange(min, max float64, horizontal, extend bool) (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	ymin, ymax = math.Inf(1), math.Inf(-1)
+	if !extend {
+		return xmin, xmax, ymin, ymax
+	}
+	if horizontal {
+		return xmin, xmax, min, max
+	}
+	return min, max, ymin, ymax
+}
+
+// Span implements the Plotter interface, shading a range of the X or
+// Y axis across the full extent of the data area, such as a period of
+// time or an acceptable range of values. Spans should usually be added
+// to a plot before the data they are drawn behind.
+type Span struct {
+	// Min and Max are the ends of the span along
+	// the X axis, or along the Y axis if the span
+	// is Horizontal.
+	Min, Max float64
+
+	// Horizontal specifies that the span is a band
+	// parallel to the X axis between the Y locations
+	// Min and Max.
+	Horizontal bool
+
+	// Color is the fill color of the span. If
+	// Color is nil the span is not filled.
+	Color color.Color
+
+	// LineStyle is the style of the edges of the
+	// span. If its width is zero the edges are not
+	// drawn.
+	LineStyle draw.LineStyle
+
+	// Label is the text drawn inside the span.
+	// Labels of vertical spans are rotated to run
+	// up the span.
+	Label string
+
+	// TextStyle is the style of the label. Its
+	// alignment and rotation are set according to
+	// the direction of the span and LabelPosition.
+	TextStyle draw.TextStyle
+
+	// LabelPosition is the position of the label
+	// along the span as a fraction of the data area,
+	// from the left or bottom end at 0 to the right
+	// or top end at 1. The label is centered across
+	// the span.
+	LabelPosition float64
+
+	// ExtendRange specifies that DataRange includes
+	// Min and Max, so that the span is always visible.
+	// By default a Span does not affect the range of
+	// its axis.
+	ExtendRange bool
+}
+
+// NewSpan returns a Span between min and max, drawn horizontally
+// if horizontal is true, using a light gray fill and the default
+// font with the label at the end of the span. An error is returned
+// if min or max are not finite or if max is less than min.
+func NewSpan(min, max float64, horizontal bool) (*Span, error) {
+	if err := CheckFinite(min, max); err != nil {
+		return nil, err
+	}
+	if max < min {
+		return nil, fmt.Errorf("plotter: invalid span [%g, %g]", min, max)
+	}
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Span{
+		Min:           min,
+		Max:           max,
+		Horizontal:    horizontal,
+		Color:         color.Gray{Y: 224},
+		TextStyle:     draw.TextStyle{Font: fnt},
+		LabelPosition: 1,
+	}, nil
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (s *Span) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	r := c.Rectangle
+	if s.Horizontal {
+		r.Min.Y, r.Max.Y = trY(s.Min), trY(s.Max)
+	} else {
+		r.Min.X, r.Max.X = trX(s.Min), trX(s.Max)
+	}
+	pts := c.ClipPolygonXY([]vg.Point{
+		r.Min, {X: r.Max.X, Y: r.Min.Y}, r.Max, {X: r.Min.X, Y: r.Max.Y},
+	})
+	if len(pts) == 0 {
+		return
+	}
+	if s.Color != nil {
+		c.FillPolygon(s.Color, pts)
+	}
+	if s.LineStyle.Width > 0 {
+		var edges [][]vg.Point
+		if s.Horizontal {
+			edges = [][]vg.Point{
+				{r.Min, {X: r.Max.X, Y: r.Min.Y}},
+				{{X: r.Min.X, Y: r.Max.Y}, r.Max},
+			}
+		} else {
+			edges = [][]vg.Point{
+				{r.Min, {X: r.Min.X, Y: r.Max.Y}},
+				{{X: r.Max.X, Y: r.Min.Y}, r.Max},
+			}
+		}
+		for _, e := range edges {
+			c.StrokeLines(s.LineStyle, c.ClipLinesXY(e)...)
+		}
+	}
+
+	if s.Label == "" {
+		return
+	}
+	sty := refLabelStyle(s.TextStyle, s.Horizontal, s.LabelPosition)
+	sty.YAlign = draw.YCenter
+	var pt vg.Point
+	if s.Horizontal {
+		pt = vg.Point{X: c.X(s.LabelPosition), Y: (r.Min.Y + r.Max.Y) / 2}
+	} else {
+		pt = vg.Point{X: (r.Min.X + r.Max.X) / 2, Y: c.Y(s.LabelPosition)}
+	}
+	if c.Contains(pt) {
+		c.FillText(sty, pt, s.Label)
+	}
+}
+
+// DataRange implements the DataRange method of the plot.DataRanger
+// interface. The returned range is empty unless ExtendRange is set.
+func (s *Span) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return refRange(s.Min, s.Max, s.Horizontal, s.ExtendRange)
+}
@@ -0,0 +1,126 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter_test
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/cmpimg"
+	"github.com/gshk/plot/plotter"
+	"github.com/gshk/plot/vg/draw"
+	"github.com/gshk/plot/vg/recorder"
+)
+
+// ExampleRefLine draws a noisy signal with an alarm threshold,
+// the time of an event and the acceptable range of the signal.
+func ExampleRefLine() {
+	f := plotter.NewFunction(func(x float64) float64 {
+		return 5 + 3*math.Sin(x) + math.Sin(7*x)
+	})
+
+	ok, err := plotter.NewSpan(3, 7, true)
+	if err != nil {
+		log.Panic(err)
+	}
+	ok.Label = "acceptable"
+	ok.LabelPosition = 0
+
+	maintenance, err := plotter.NewSpan(6, 7, false)
+	if err != nil {
+		log.Panic(err)
+	}
+	maintenance.Label = "maintenance"
+	maintenance.LabelPosition = 0.5
+
+	alarm, err := plotter.NewRefLine(8.5, true)
+	if err != nil {
+		log.Panic(err)
+	}
+	alarm.Label = "alarm"
+	alarm.ExtendRange = true
+
+	event, err := plotter.NewRefLine(2, false)
+	if err != nil {
+		log.Panic(err)
+	}
+	event.Label = "restart"
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.X.Min, p.X.Max = 0, 10
+	p.Y.Min, p.Y.Max = 0, 8
+	p.Add(ok, maintenance, f, alarm, event)
+
+	err = p.Save(300, 200, "testdata/refline.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestRefLineExample(t *testing.T) {
+	cmpimg.CheckPlot(ExampleRefLine, t, "refline.png")
+}
+
+func TestRefLine(t *testing.T) {
+	r, err := plotter.NewRefLine(5, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	xmin, xmax, ymin, ymax := r.DataRange()
+	if !math.IsInf(xmin, 1) || !math.IsInf(xmax, -1) || !math.IsInf(ymin, 1) || !math.IsInf(ymax, -1) {
+		t.Errorf("unexpected data range: got:%v %v %v %v want:empty range", xmin, xmax, ymin, ymax)
+	}
+	r.ExtendRange = true
+	xmin, xmax, ymin, ymax = r.DataRange()
+	if !math.IsInf(xmin, 1) || !math.IsInf(xmax, -1) || ymin != 5 || ymax != 5 {
+		t.Errorf("unexpected extended data range: got:%v %v %v %v want:+Inf -Inf 5 5", xmin, xmax, ymin, ymax)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.X.Min, p.X.Max = 0, 1
+	p.Y.Min, p.Y.Max = 0, 1
+	r.ExtendRange = false
+	p.Add(r)
+	if p.Y.Max != 1 {
+		t.Errorf("reference line changed axis range: got:%v want:1", p.Y.Max)
+	}
+
+	// The line is outside the data area, so nothing is drawn.
+	var c recorder.Canvas
+	r.Plot(draw.NewCanvas(&c, 100, 100), p)
+	if len(c.Actions) != 0 {
+		t.Errorf("unexpected drawing of reference line outside data area: %d actions", len(c.Actions))
+	}
+
+	if _, err := plotter.NewRefLine(math.NaN(), false); err == nil {
+		t.Error("expected error for NaN value")
+	}
+}
+
+func TestSpan(t *testing.T) {
+	s, err := plotter.NewSpan(1, 2, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.ExtendRange = true
+	xmin, xmax, ymin, ymax := s.DataRange()
+	if xmin != 1 || xmax != 2 || !math.IsInf(ymin, 1) || !math.IsInf(ymax, -1) {
+		t.Errorf("unexpected extended data range: got:%v %v %v %v want:1 2 +Inf -Inf", xmin, xmax, ymin, ymax)
+	}
+
+	for _, r := range [][2]float64{{2, 1}, {math.NaN(), 1}, {0, math.Inf(1)}} {
+		if _, err := plotter.NewSpan(r[0], r[1], true); err == nil {
+			t.Errorf("expected error for span %v", r)
+		}
+	}
+}
@@ -5,6 +5,8 @@
 package plotter
 
 import (
+	"math"
+
 	"github.com/gshk/plot"
 	"github.com/gshk/plot/vg"
 	"github.com/gshk/plot/vg/draw"
@@ -47,6 +49,9 @@ func (pts *Scatter) Plot(c draw.Canvas, plt *plot.Plot) {
 		glyph = pts.GlyphStyleFunc
 	}
 	for i, p := range pts.XYs {
+		if math.IsNaN(p.X) || math.IsNaN(p.Y) {
+			continue
+		}
 		c.DrawGlyph(glyph(i), vg.Point{X: trX(p.X), Y: trY(p.Y)})
 	}
 }
@@ -65,15 +70,20 @@ func (pts *Scatter) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 	if pts.GlyphStyleFunc != nil {
 		glyph = pts.GlyphStyleFunc
 	}
-	bs := make([]plot.GlyphBox, len(pts.XYs))
+	bs := make([]plot.GlyphBox, 0, len(pts.XYs))
 	for i, p := range pts.XYs {
-		bs[i].X = plt.X.Norm(p.X)
-		bs[i].Y = plt.Y.Norm(p.Y)
-		r := glyph(i).Radius
-		bs[i].Rectangle = vg.Rectangle{
-			Min: vg.Point{X: -r, Y: -r},
-			Max: vg.Point{X: +r, Y: +r},
+		if math.IsNaN(p.X) || math.IsNaN(p.Y) {
+			continue
 		}
+		r := glyph(i).Radius
+		bs = append(bs, plot.GlyphBox{
+			X: plt.X.Norm(p.X),
+			Y: plt.Y.Norm(p.Y),
+			Rectangle: vg.Rectangle{
+				Min: vg.Point{X: -r, Y: -r},
+				Max: vg.Point{X: +r, Y: +r},
+			},
+		})
 	}
 	return bs
 }
@@ -7,6 +7,7 @@ package plotter_test
 import (
 	"image/color"
 	"log"
+	"math"
 	"testing"
 
 	"golang.org/x/exp/rand"
@@ -89,3 +90,23 @@ func ExampleScatter() {
 func TestScatter(t *testing.T) {
 	cmpimg.CheckPlot(ExampleScatter, t, "scatter.png")
 }
+
+func TestScatterGaps(t *testing.T) {
+	s, err := plotter.NewScatter(plotter.XYs{{X: 0, Y: 1}, {X: math.NaN(), Y: 5}, {X: 2, Y: math.NaN()}, {X: 3, Y: 0}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	xmin, xmax, ymin, ymax := s.DataRange()
+	if xmin != 0 || xmax != 3 || ymin != 0 || ymax != 1 {
+		t.Errorf("unexpected data range: got:%v %v %v %v want:0 3 0 1", xmin, xmax, ymin, ymax)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(s)
+	if got := len(s.GlyphBoxes(p)); got != 2 {
+		t.Errorf("unexpected number of glyph boxes: got:%d want:2", got)
+	}
+}
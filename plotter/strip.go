@@ -0,0 +1,338 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"golang.org/x/exp/rand"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/draw"
+)
+
+// Jitter specifies how the glyphs of a Strip are
+// displaced across the category axis.
+type Jitter int
+
+const (
+	// RandomJitter displaces the glyphs by uniformly
+	// distributed random amounts drawn from a source
+	// seeded by the Seed of the Strip, so that the
+	// same strip is drawn each time.
+	RandomJitter Jitter = iota
+
+	// DeterministicJitter displaces the glyphs in the
+	// order of their values by the fractional parts of
+	// multiples of the golden ratio, which spreads
+	// neighboring values evenly across the strip.
+	DeterministicJitter
+
+	// NoJitter draws all of the glyphs on the
+	// category axis at the location of the strip.
+	NoJitter
+)
+
+// Strip implements the Plotter interface, drawing a glyph for each
+// value of a category along a strip at the location of the category,
+// with the glyphs displaced across the strip to reduce overlap.
+type Strip struct {
+	// Values is a copy of the values of the strip.
+	Values
+
+	// Location is the location of the strip
+	// along the category axis.
+	Location float64
+
+	// Offset is added to the location of the strip.
+	Offset vg.Length
+
+	// Width is the width of the strip across
+	// which the glyphs are displaced.
+	Width vg.Length
+
+	// Jitter is the method of displacing the glyphs.
+	Jitter Jitter
+
+	// Seed is the seed of the source of random
+	// displacements used by RandomJitter.
+	Seed uint64
+
+	// GlyphStyle is the style of the glyphs.
+	draw.GlyphStyle
+
+	// Horizontal specifies that the values are placed
+	// along the X axis and the categories along the Y
+	// axis.
+	Horizontal bool
+}
+
+// NewStrip returns a Strip of the given width at the location loc
+// that draws the given values with random jitter, using the default
+// glyph style.
+func NewStrip(w vg.Length, loc float64, values Valuer) (*Strip, error) {
+	if w < 0 {
+		return nil, errors.New("plotter: negative strip width")
+	}
+	vs, err := CopyValues(values)
+	if err != nil {
+		return nil, err
+	}
+	return &Strip{
+		Values:     vs,
+		Location:   loc,
+		Width:      w,
+		GlyphStyle: DefaultGlyphStyle,
+	}, nil
+}
+
+// offsets returns the displacements of the glyphs across
+// the strip.
+func (s *Strip) offsets() []vg.Length {
+	offs := make([]vg.Length, len(s.Values))
+	switch s.Jitter {
+	case RandomJitter:
+		rnd := rand.New(rand.NewSource(s.Seed))
+		for i := range offs {
+			offs[i] = vg.Length(rnd.Float64()-0.5) * s.Width
+		}
+	case DeterministicJitter:
+		const phi = 1.6180339887498949
+		for rank, i := range valueOrder(s.Values) {
+			_, f := math.Modf(float64(rank) * phi)
+			offs[i] = vg.Length(f-0.5) * s.Width
+		}
+	case NoJitter:
+	default:
+		panic("plotter: unknown jitter")
+	}
+	return offs
+}
+
+// valueOrder returns the indices of vs in increasing order of value.
+func valueOrder(vs Values) []int {
+	order := make([]int, len(vs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return vs[order[i]] < vs[order[j]] })
+	return order
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (s *Strip) Plot(c draw.Canvas, plt *plot.Plot) {
+	trLoc, trVal, pt := categoryTransforms(&c, plt, s.Horizontal)
+	x := trLoc(s.Location) + s.Offset
+	for i, off := range s.offsets() {
+		v := s.Values[i]
+		if math.IsNaN(v) {
+			continue
+		}
+		c.DrawGlyphNoClip(s.GlyphStyle, pt(x+off, trVal(v)))
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (s *Strip) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return categoryRange(s.Location, s.Values, s.Horizontal)
+}
+
+// GlyphBoxes implements the GlyphBoxes method
+// of the plot.GlyphBoxer interface.
+func (s *Strip) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	return categoryGlyphBoxes(plt, s.Location, s.Values, s.Offset, s.Width/2, s.Radius, s.Horizontal)
+}
+
+// Thumbnail implements the Thumbnail method
+// of the plot.Thumbnailer interface.
+func (s *Strip) Thumbnail(c *draw.Canvas) {
+	c.DrawGlyph(s.GlyphStyle, c.Center())
+}
+
+// Beeswarm implements the Plotter interface, drawing a glyph for each
+// value of a category with the glyphs packed across the category axis
+// so that they do not overlap. Glyphs are placed as close as possible
+// to the location of the category in increasing order of value, so
+// the width of the swarm shows the density of the values.
+type Beeswarm struct {
+	// Values is a copy of the values of the swarm.
+	Values
+
+	// Location is the location of the swarm
+	// along the category axis.
+	Location float64
+
+	// Offset is added to the location of the swarm.
+	Offset vg.Length
+
+	// Gap is the minimum space between glyphs.
+	Gap vg.Length
+
+	// GlyphStyle is the style of the glyphs. The
+	// glyphs are packed according to their Radius.
+	draw.GlyphStyle
+
+	// Horizontal specifies that the values are placed
+	// along the X axis and the categories along the Y
+	// axis.
+	Horizontal bool
+}
+
+// NewBeeswarm returns a Beeswarm at the location loc that draws
+// the given values using the default glyph style.
+func NewBeeswarm(loc float64, values Valuer) (*Beeswarm, error) {
+	vs, err := CopyValues(values)
+	if err != nil {
+		return nil, err
+	}
+	return &Beeswarm{
+		Values:     vs,
+		Location:   loc,
+		Gap:        vg.Points(0.5),
+		GlyphStyle: DefaultGlyphStyle,
+	}, nil
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (b *Beeswarm) Plot(c draw.Canvas, plt *plot.Plot) {
+	trLoc, trVal, pt := categoryTransforms(&c, plt, b.Horizontal)
+	x := trLoc(b.Location) + b.Offset
+	var vs []vg.Length
+	for _, i := range valueOrder(b.Values) {
+		if v := b.Values[i]; !math.IsNaN(v) {
+			vs = append(vs, trVal(v))
+		}
+	}
+	for i, off := range swarm(vs, 2*b.Radius+b.Gap) {
+		c.DrawGlyphNoClip(b.GlyphStyle, pt(x+off, vs[i]))
+	}
+}
+
+// swarm returns the displacements across the category axis of glyphs
+// at the sorted positions vs along the value axis, such that the
+// centers of the glyphs are at least d apart. Each glyph is placed at
+// the smallest displacement that does not overlap the glyphs before it.
+func swarm(vs []vg.Length, d vg.Length) []vg.Length {
+	offs := make([]vg.Length, len(vs))
+	// start is the index of the first glyph that
+	// may overlap the glyph being placed.
+	start := 0
+	for i, v := range vs {
+		for start < i && v-vs[start] >= d {
+			start++
+		}
+		// The candidate displacements are zero and the
+		// positions touching each of the neighbors.
+		cands := []vg.Length{0}
+		for j := start; j < i; j++ {
+			dv := float64(v - vs[j])
+			da := vg.Length(math.Sqrt(math.Max(0, float64(d*d)-dv*dv)))
+			cands = append(cands, offs[j]-da, offs[j]+da)
+		}
+		sort.Slice(cands, func(a, b int) bool {
+			ca, cb := math.Abs(float64(cands[a])), math.Abs(float64(cands[b]))
+			if ca != cb {
+				return ca < cb
+			}
+			return cands[a] < cands[b]
+		})
+		for _, a := range cands {
+			if !overlapsSwarm(a, v, vs[start:i], offs[start:i], d) {
+				offs[i] = a
+				break
+			}
+		}
+	}
+	return offs
+}
+
+// overlapsSwarm returns whether a glyph at the displacement a and
+// value position v is closer than d to any of the glyphs at vs and offs.
+func overlapsSwarm(a, v vg.Length, vs, offs []vg.Length, d vg.Length) bool {
+	// Allow for rounding in the touching
+	// positions of the candidates.
+	const eps = 1e-9
+	for j := range vs {
+		dv, da := float64(v-vs[j]), float64(a-offs[j])
+		if dv*dv+da*da < float64(d*d)*(1-eps) {
+			return true
+		}
+	}
+	return false
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (b *Beeswarm) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return categoryRange(b.Location, b.Values, b.Horizontal)
+}
+
+// GlyphBoxes implements the GlyphBoxes method of the
+// plot.GlyphBoxer interface. The width of the swarm depends
+// on the size of the canvas, so only the glyphs at the
+// location of the swarm are included.
+func (b *Beeswarm) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	return categoryGlyphBoxes(plt, b.Location, b.Values, b.Offset, 0, b.Radius, b.Horizontal)
+}
+
+// Thumbnail implements the Thumbnail method
+// of the plot.Thumbnailer interface.
+func (b *Beeswarm) Thumbnail(c *draw.Canvas) {
+	c.DrawGlyph(b.GlyphStyle, c.Center())
+}
+
+// categoryTransforms returns the transforms of the category
+// and value axes, and a function returning the canvas point at
+// the position a along the category axis and v along the value
+// axis.
+func categoryTransforms(c *draw.Canvas, plt *plot.Plot, horizontal bool) (trLoc, trVal func(float64) vg.Length, pt func(a, v vg.Length) vg.Point) {
+	trX, trY := plt.Transforms(c)
+	if horizontal {
+		return trY, trX, func(a, v vg.Length) vg.Point { return vg.Point{X: v, Y: a} }
+	}
+	return trX, trY, func(a, v vg.Length) vg.Point { return vg.Point{X: a, Y: v} }
+}
+
+// categoryRange returns the data range of values of a category
+// at the location loc.
+func categoryRange(loc float64, vs Values, horizontal bool) (xmin, xmax, ymin, ymax float64) {
+	min, max := Range(vs)
+	if horizontal {
+		return min, max, loc, loc
+	}
+	return loc, loc, min, max
+}
+
+// categoryGlyphBoxes returns the glyph boxes of glyphs of radius r
+// for values of a category at the location loc, offset by off and
+// extending by half across the category axis.
+func categoryGlyphBoxes(plt *plot.Plot, loc float64, vs Values, off, half, r vg.Length, horizontal bool) []plot.GlyphBox {
+	rect := vg.Rectangle{
+		Min: vg.Point{X: off - half - r, Y: -r},
+		Max: vg.Point{X: off + half + r, Y: r},
+	}
+	if horizontal {
+		rect.Min.X, rect.Min.Y = rect.Min.Y, rect.Min.X
+		rect.Max.X, rect.Max.Y = rect.Max.Y, rect.Max.X
+	}
+	bs := make([]plot.GlyphBox, 0, len(vs))
+	for _, v := range vs {
+		if math.IsNaN(v) {
+			continue
+		}
+		b := plot.GlyphBox{Rectangle: rect}
+		if horizontal {
+			b.X, b.Y = plt.X.Norm(v), plt.Y.Norm(loc)
+		} else {
+			b.X, b.Y = plt.X.Norm(loc), plt.Y.Norm(v)
+		}
+		bs = append(bs, b)
+	}
+	return bs
+}
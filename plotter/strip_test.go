@@ -0,0 +1,182 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter_test
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/cmpimg"
+	"github.com/gshk/plot/plotter"
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/draw"
+	"github.com/gshk/plot/vg/recorder"
+)
+
+// ExampleBeeswarm draws every observation of three categories as a
+// beeswarm beside a jittered strip of the same values, over their
+// box plots.
+func ExampleBeeswarm() {
+	rnd := rand.New(rand.NewSource(1))
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Reaction times"
+	for i := 0; i < 3; i++ {
+		vs := make(plotter.Values, 60)
+		for j := range vs {
+			vs[j] = 250 + 40*float64(i) + 30*rnd.NormFloat64()
+		}
+
+		box, err := plotter.NewBoxPlot(vg.Points(40), float64(i), vs)
+		if err != nil {
+			log.Panic(err)
+		}
+		box.GlyphStyle.Radius = 0
+
+		swarm, err := plotter.NewBeeswarm(float64(i), vs)
+		if err != nil {
+			log.Panic(err)
+		}
+		swarm.Color = color.RGBA{B: 196, A: 255}
+		swarm.Radius = vg.Points(1.5)
+
+		strip, err := plotter.NewStrip(vg.Points(20), float64(i), vs)
+		if err != nil {
+			log.Panic(err)
+		}
+		strip.Jitter = plotter.DeterministicJitter
+		strip.Offset = vg.Points(40)
+		strip.Color = color.NRGBA{R: 196, A: 128}
+		strip.Radius = vg.Points(1.5)
+
+		p.Add(box, swarm, strip)
+	}
+	p.NominalX("A", "B", "C")
+
+	err = p.Save(300, 250, "testdata/beeswarm.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestBeeswarmExample(t *testing.T) {
+	cmpimg.CheckPlot(ExampleBeeswarm, t, "beeswarm.png")
+}
+
+// glyphCenters returns the centers of the circle and ring
+// glyphs recorded in actions.
+func glyphCenters(actions []recorder.Action) []vg.Point {
+	var centers []vg.Point
+	for _, a := range actions {
+		var path vg.Path
+		switch a := a.(type) {
+		case *recorder.Fill:
+			path = a.Path
+		case *recorder.Stroke:
+			path = a.Path
+		}
+		for _, comp := range path {
+			if comp.Type == vg.ArcComp {
+				centers = append(centers, comp.Pos)
+			}
+		}
+	}
+	return centers
+}
+
+func TestBeeswarm(t *testing.T) {
+	for _, horizontal := range []bool{false, true} {
+		vs := make(plotter.Values, 200)
+		rnd := rand.New(rand.NewSource(1))
+		for i := range vs {
+			vs[i] = rnd.NormFloat64()
+		}
+		b, err := plotter.NewBeeswarm(0, vs)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		b.Horizontal = horizontal
+		b.Gap = 0
+
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.Add(b)
+		if horizontal {
+			p.NominalY("A")
+		} else {
+			p.NominalX("A")
+		}
+		var rec recorder.Canvas
+		p.Draw(draw.NewCanvas(&rec, 300, 300))
+
+		centers := glyphCenters(rec.Actions)
+		if len(centers) != len(vs) {
+			t.Fatalf("unexpected number of glyphs: got:%d want:%d", len(centers), len(vs))
+		}
+		d := 2 * b.Radius
+		var spread bool
+		for i, a := range centers {
+			for _, c := range centers[i+1:] {
+				if dist := math.Hypot(float64(a.X-c.X), float64(a.Y-c.Y)); dist < float64(d)-1e-6 {
+					t.Fatalf("glyphs overlap with horizontal=%t: %v and %v are %v apart", horizontal, a, c, dist)
+				}
+			}
+			if (horizontal && a.Y != centers[0].Y) || (!horizontal && a.X != centers[0].X) {
+				spread = true
+			}
+		}
+		if !spread {
+			t.Errorf("glyphs not spread across the category axis with horizontal=%t", horizontal)
+		}
+	}
+}
+
+func TestStrip(t *testing.T) {
+	vs := plotter.Values{1, 2, 3, math.NaN(), 4}
+	for _, jitter := range []plotter.Jitter{plotter.RandomJitter, plotter.DeterministicJitter, plotter.NoJitter} {
+		s, err := plotter.NewStrip(vg.Points(20), 1, vs)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		s.Jitter = jitter
+		s.Horizontal = true
+		xmin, xmax, ymin, ymax := s.DataRange()
+		if xmin != 1 || xmax != 4 || ymin != 1 || ymax != 1 {
+			t.Errorf("unexpected data range: got:%v %v %v %v want:1 4 1 1", xmin, xmax, ymin, ymax)
+		}
+
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.Add(s)
+		var first, second recorder.Canvas
+		p.Draw(draw.NewCanvas(&first, 200, 200))
+		p.Draw(draw.NewCanvas(&second, 200, 200))
+
+		a, b := glyphCenters(first.Actions), glyphCenters(second.Actions)
+		if len(a) != 4 {
+			t.Fatalf("unexpected number of glyphs for jitter %d: got:%d want:4", jitter, len(a))
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				t.Errorf("jitter %d not reproducible: got:%v and %v", jitter, a[i], b[i])
+			}
+			if d := math.Abs(float64(a[i].Y - a[0].Y)); d > float64(s.Width) {
+				t.Errorf("glyph %d displaced beyond strip width: %v", i, d)
+			}
+		}
+	}
+}
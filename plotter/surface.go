@@ -0,0 +1,401 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/palette"
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/draw"
+)
+
+// Surface implements the Plotter interface, drawing an orthographic
+// projection of the surface of the values in a GridXYZ, such as the
+// data of a HeatMap, with the Z values as heights above the X-Y plane.
+//
+// The data are scaled to fill a unit cube that is viewed by a camera
+// placed according to Azimuth and Elevation. The X and Y axes of the
+// plot hold the projected coordinates of the cube, so they should
+// normally be hidden and given equal scales, and the Surface draws its
+// own projected axes.
+type Surface struct {
+	GridXYZ GridXYZ
+
+	// Palette is used to fill the quadrilaterals between
+	// neighboring grid points by their mean Z value. The
+	// quadrilaterals are filled from the farthest to the
+	// nearest so that nearer parts of the surface hide
+	// those behind them. If Palette is nil the surface
+	// is drawn as a wireframe.
+	Palette palette.Palette
+
+	// Min and Max are the range of Z values spanned by
+	// the height of the cube and by the Palette. Values
+	// outside the range are drawn at the nearest limit.
+	Min, Max float64
+
+	// Azimuth is the angle in radians of the camera
+	// around the Z axis, counterclockwise from the
+	// positive X axis when viewed from above.
+	Azimuth float64
+
+	// Elevation is the angle in radians of the
+	// camera above the X-Y plane.
+	Elevation float64
+
+	// LineStyle is the style of the wireframe lines.
+	// If its width is zero the lines are not drawn.
+	LineStyle draw.LineStyle
+
+	// Axes specifies whether the projected
+	// axes are drawn.
+	Axes bool
+
+	// AxisStyle is the style of the axis
+	// lines and tick marks.
+	AxisStyle draw.LineStyle
+
+	// TextStyle is the style of the tick
+	// and axis labels.
+	TextStyle draw.TextStyle
+
+	// XTicks, YTicks and ZTicks are the tickers
+	// of the axes. Only labeled ticks are drawn.
+	XTicks, YTicks, ZTicks plot.Ticker
+
+	// XLabel, YLabel and ZLabel are the labels
+	// of the axes.
+	XLabel, YLabel, ZLabel string
+}
+
+// NewSurface returns a Surface of g filled using the palette p, viewed
+// from a camera at an elevation of 30° and an azimuth of -60°. If g
+// has Min and Max methods that return a float, those returned values
+// are used to set the respective Surface fields, as for NewHeatMap.
+func NewSurface(g GridXYZ, p palette.Palette) (*Surface, error) {
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	h := NewHeatMap(g, p)
+	return &Surface{
+		GridXYZ:   g,
+		Palette:   p,
+		Min:       h.Min,
+		Max:       h.Max,
+		Azimuth:   -math.Pi / 3,
+		Elevation: math.Pi / 6,
+		LineStyle: draw.LineStyle{
+			Color: color.Gray{Y: 64},
+			Width: vg.Points(0.25),
+		},
+		Axes:      true,
+		AxisStyle: DefaultLineStyle,
+		TextStyle: draw.TextStyle{
+			Font:   fnt,
+			XAlign: draw.XCenter,
+			YAlign: draw.YCenter,
+		},
+		XTicks: plot.DefaultTicks{},
+		YTicks: plot.DefaultTicks{},
+		ZTicks: plot.DefaultTicks{},
+	}, nil
+}
+
+// vec3 is a point in the unit cube centered on the origin.
+type vec3 [3]float64
+
+func (v vec3) dot(w vec3) float64 { return v[0]*w[0] + v[1]*w[1] + v[2]*w[2] }
+
+func (v vec3) add(w vec3, s float64) vec3 {
+	return vec3{v[0] + s*w[0], v[1] + s*w[1], v[2] + s*w[2]}
+}
+
+// surfaceView is the projection of the data of a Surface.
+type surfaceView struct {
+	min, max vec3
+
+	// right and up are the directions of the
+	// projected X and Y axes, and toward is the
+	// direction of the camera.
+	right, up, toward vec3
+}
+
+// view returns the projection of the surface.
+func (s *Surface) view() surfaceView {
+	cols, rows := s.GridXYZ.Dims()
+	v := surfaceView{
+		min: vec3{s.GridXYZ.X(0), s.GridXYZ.Y(0), s.Min},
+		max: vec3{s.GridXYZ.X(cols - 1), s.GridXYZ.Y(rows - 1), s.Max},
+	}
+	for i := 0; i < 2; i++ {
+		if v.min[i] > v.max[i] {
+			v.min[i], v.max[i] = v.max[i], v.min[i]
+		}
+	}
+	saz, caz := math.Sincos(s.Azimuth)
+	sel, cel := math.Sincos(s.Elevation)
+	v.right = vec3{-saz, caz, 0}
+	v.up = vec3{-sel * caz, -sel * saz, cel}
+	v.toward = vec3{cel * caz, cel * saz, sel}
+	return v
+}
+
+// norm returns the location in the unit cube of the
+// data point at x, y and z.
+func (v surfaceView) norm(x, y, z float64) vec3 {
+	p := vec3{x, y, z}
+	for i := range p {
+		if v.max[i] > v.min[i] {
+			p[i] = (math.Max(v.min[i], math.Min(v.max[i], p[i]))-v.min[i])/(v.max[i]-v.min[i]) - 0.5
+		} else {
+			p[i] = 0
+		}
+	}
+	return p
+}
+
+// project returns the projected location of p in the data
+// coordinates of the plot.
+func (v surfaceView) project(p vec3) XY {
+	return XY{X: p.dot(v.right), Y: p.dot(v.up)}
+}
+
+// surfaceQuad is a projected quadrilateral of a Surface.
+type surfaceQuad struct {
+	corners [4]XY
+	depth   float64
+	z       float64
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (s *Surface) Plot(c draw.Canvas, plt *plot.Plot) {
+	if s.Min > s.Max {
+		panic("plotter: invalid Z range: min greater than max")
+	}
+	trX, trY := plt.Transforms(&c)
+	pt := func(p XY) vg.Point { return vg.Point{X: trX(p.X), Y: trY(p.Y)} }
+	v := s.view()
+
+	cols, rows := s.GridXYZ.Dims()
+	at := func(i, j int) (vec3, bool) {
+		z := s.GridXYZ.Z(i, j)
+		return v.norm(s.GridXYZ.X(i), s.GridXYZ.Y(j), z), !math.IsNaN(z)
+	}
+
+	if s.Palette != nil {
+		pal := s.Palette.Colors()
+		if len(pal) == 0 {
+			panic("plotter: empty palette")
+		}
+		var quads []surfaceQuad
+		for i := 0; i < cols-1; i++ {
+			for j := 0; j < rows-1; j++ {
+				var q surfaceQuad
+				ok := true
+				for k, ij := range [4][2]int{{i, j}, {i + 1, j}, {i + 1, j + 1}, {i, j + 1}} {
+					p, valid := at(ij[0], ij[1])
+					ok = ok && valid
+					q.corners[k] = v.project(p)
+					q.depth += p.dot(v.toward) / 4
+					q.z += s.GridXYZ.Z(ij[0], ij[1]) / 4
+				}
+				if ok {
+					quads = append(quads, q)
+				}
+			}
+		}
+		sort.SliceStable(quads, func(a, b int) bool { return quads[a].depth < quads[b].depth })
+
+		// ps scales the palette uniformly across the data range.
+		ps := float64(len(pal)-1) / (s.Max - s.Min)
+		for _, q := range quads {
+			idx := 0
+			if !math.IsInf(ps, 0) {
+				idx = int((math.Max(s.Min, math.Min(s.Max, q.z))-s.Min)*ps + 0.5)
+			}
+			pts := make([]vg.Point, 4, 5)
+			for k, p := range q.corners {
+				pts[k] = pt(p)
+			}
+			c.FillPolygon(pal[idx], c.ClipPolygonXY(pts))
+			if s.LineStyle.Width != 0 {
+				c.StrokeLines(s.LineStyle, c.ClipLinesXY(append(pts, pts[0]))...)
+			}
+		}
+	} else if s.LineStyle.Width != 0 {
+		// Draw each line of the wireframe, breaking
+		// it at NaN values.
+		line := func(n int, p func(k int) (vec3, bool)) {
+			var ps []vg.Point
+			for k := 0; k <= n; k++ {
+				var q vec3
+				valid := false
+				if k < n {
+					q, valid = p(k)
+				}
+				if valid {
+					ps = append(ps, pt(v.project(q)))
+					continue
+				}
+				if len(ps) > 1 {
+					c.StrokeLines(s.LineStyle, c.ClipLinesXY(ps)...)
+				}
+				ps = ps[:0]
+			}
+		}
+		for i := 0; i < cols; i++ {
+			line(rows, func(j int) (vec3, bool) { return at(i, j) })
+		}
+		for j := 0; j < rows; j++ {
+			line(cols, func(i int) (vec3, bool) { return at(i, j) })
+		}
+	}
+
+	if s.Axes {
+		for _, a := range s.axes(v) {
+			c.StrokeLines(s.AxisStyle, c.ClipLinesXY([]vg.Point{pt(a.from), pt(a.to)})...)
+			for _, t := range a.ticks {
+				c.StrokeLines(s.AxisStyle, c.ClipLinesXY([]vg.Point{pt(t.from), pt(t.to)})...)
+			}
+			for _, l := range a.labels {
+				c.FillText(s.TextStyle, pt(l.at), l.text)
+			}
+		}
+	}
+}
+
+// surfaceAxis is a projected axis of a Surface.
+type surfaceAxis struct {
+	from, to XY
+	ticks    []struct{ from, to XY }
+	labels   []surfaceLabel
+}
+
+// surfaceLabel is a projected label of a Surface.
+type surfaceLabel struct {
+	at   XY
+	text string
+}
+
+// Distances from the axes of the tick mark ends, the tick labels and
+// the axis labels, in the units of the unit cube.
+const (
+	surfaceTickLength = 0.03
+	surfaceTickLabel  = 0.1
+	surfaceAxisLabel  = 0.22
+)
+
+// axes returns the projected X, Y and Z axes. The X and Y axes lie
+// along the edges of the bottom of the cube nearest the camera and the
+// Z axis lies along the vertical edge at the left of the projection.
+func (s *Surface) axes(v surfaceView) []surfaceAxis {
+	// Choose the sides of the cube
+	// nearest the camera.
+	sx, sy := -0.5, -0.5
+	if v.toward[0] > 0 {
+		sx = 0.5
+	}
+	if v.toward[1] > 0 {
+		sy = 0.5
+	}
+	// Choose the bottom corner at the left of the
+	// projection for the Z axis, excluding the corner
+	// nearest the camera.
+	zc := vec3{-sx, sy, -0.5}
+	if alt := (vec3{sx, -sy, -0.5}); alt.dot(v.right) < zc.dot(v.right) {
+		zc = alt
+	}
+
+	var axes []surfaceAxis
+	for _, a := range []struct {
+		dim       int
+		base, out vec3
+		ticker    plot.Ticker
+		label     string
+		min, max  float64
+	}{
+		{dim: 0, base: vec3{0, sy, -0.5}, out: vec3{0, 2 * sy, 0}, ticker: s.XTicks, label: s.XLabel},
+		{dim: 1, base: vec3{sx, 0, -0.5}, out: vec3{2 * sx, 0, 0}, ticker: s.YTicks, label: s.YLabel},
+		{dim: 2, base: vec3{zc[0], zc[1], 0}, out: vec3{zc[0] * math.Sqrt2, zc[1] * math.Sqrt2, 0}, ticker: s.ZTicks, label: s.ZLabel},
+	} {
+		from, to := a.base, a.base
+		from[a.dim], to[a.dim] = -0.5, 0.5
+		ax := surfaceAxis{from: v.project(from), to: v.project(to)}
+		if a.ticker != nil {
+			for _, t := range a.ticker.Ticks(v.min[a.dim], v.max[a.dim]) {
+				if t.Label == "" || t.Value < v.min[a.dim] || t.Value > v.max[a.dim] {
+					continue
+				}
+				p := a.base
+				p[a.dim] = v.norm(t.Value, t.Value, t.Value)[a.dim]
+				ax.ticks = append(ax.ticks, struct{ from, to XY }{
+					from: v.project(p),
+					to:   v.project(p.add(a.out, surfaceTickLength)),
+				})
+				ax.labels = append(ax.labels, surfaceLabel{
+					at:   v.project(p.add(a.out, surfaceTickLabel)),
+					text: t.Label,
+				})
+			}
+		}
+		if a.label != "" {
+			ax.labels = append(ax.labels, surfaceLabel{
+				at:   v.project(a.base.add(a.out, surfaceAxisLabel)),
+				text: a.label,
+			})
+		}
+		axes = append(axes, ax)
+	}
+	return axes
+}
+
+// DataRange implements the DataRange method of the
+// plot.DataRanger interface. The range is the extent
+// of the projection of the unit cube and of the
+// locations of the axis labels.
+func (s *Surface) DataRange() (xmin, xmax, ymin, ymax float64) {
+	v := s.view()
+	var pts []XY
+	for _, x := range []float64{-0.5, 0.5} {
+		for _, y := range []float64{-0.5, 0.5} {
+			for _, z := range []float64{-0.5, 0.5} {
+				pts = append(pts, v.project(vec3{x, y, z}))
+			}
+		}
+	}
+	if s.Axes {
+		for _, a := range s.axes(v) {
+			for _, l := range a.labels {
+				pts = append(pts, l.at)
+			}
+		}
+	}
+	return XYRange(XYs(pts))
+}
+
+// GlyphBoxes implements the GlyphBoxes method of the
+// plot.GlyphBoxer interface, returning the extents of
+// the tick and axis labels.
+func (s *Surface) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	if !s.Axes {
+		return nil
+	}
+	var bs []plot.GlyphBox
+	for _, a := range s.axes(s.view()) {
+		for _, l := range a.labels {
+			bs = append(bs, plot.GlyphBox{
+				X:         plt.X.Norm(l.at.X),
+				Y:         plt.Y.Norm(l.at.Y),
+				Rectangle: s.TextStyle.Rectangle(l.text),
+			})
+		}
+	}
+	return bs
+}
@@ -0,0 +1,124 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter_test
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/cmpimg"
+	"github.com/gshk/plot/palette"
+	"github.com/gshk/plot/plotter"
+	"github.com/gshk/plot/vg/draw"
+	"github.com/gshk/plot/vg/recorder"
+)
+
+// ExampleSurface draws a shaded surface of a damped
+// ripple, the same data that might be shown in a
+// HeatMap.
+func ExampleSurface() {
+	const n = 25
+	m := mat.NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			x, y := float64(j-n/2)/3, float64(i-n/2)/3
+			r := math.Hypot(x, y)
+			m.Set(i, j, math.Cos(2*r)*math.Exp(-r/3))
+		}
+	}
+	g := offsetUnitGrid{XOffset: -n / 2, YOffset: -n / 2, Data: m}
+
+	s, err := plotter.NewSurface(g, palette.Heat(32, 1))
+	if err != nil {
+		log.Panic(err)
+	}
+	s.XLabel, s.YLabel, s.ZLabel = "x", "y", "z"
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Damped ripple"
+	p.Add(s)
+	p.HideAxes()
+
+	err = p.Save(300, 300, "testdata/surface.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestSurfaceExample(t *testing.T) {
+	cmpimg.CheckPlot(ExampleSurface, t, "surface.png")
+}
+
+func TestSurface(t *testing.T) {
+	g := offsetUnitGrid{Data: mat.NewDense(3, 4, []float64{
+		0, 1, 2, 3,
+		1, 2, math.NaN(), 4,
+		2, 3, 4, 5,
+	})}
+	s, err := plotter.NewSurface(g, palette.Heat(8, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Min != 0 || s.Max != 5 {
+		t.Errorf("unexpected Z range: got:[%v, %v] want:[0, 5]", s.Min, s.Max)
+	}
+
+	// Looking straight down the X axis from the positive
+	// side, the projection of the unit cube is the unit
+	// square of Y and Z.
+	s.Azimuth, s.Elevation = 0, 0
+	s.Axes = false
+	xmin, xmax, ymin, ymax := s.DataRange()
+	for _, v := range []struct{ got, want float64 }{{xmin, -0.5}, {xmax, 0.5}, {ymin, -0.5}, {ymax, 0.5}} {
+		if math.Abs(v.got-v.want) > 1e-12 {
+			t.Errorf("unexpected data range: got:%v %v %v %v want:-0.5 0.5 -0.5 0.5", xmin, xmax, ymin, ymax)
+			break
+		}
+	}
+
+	// Quadrilaterals touching the NaN value are not drawn.
+	s.Azimuth, s.Elevation = -math.Pi/3, math.Pi/6
+	s.LineStyle.Width = 0
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(s)
+	var rec recorder.Canvas
+	s.Plot(draw.NewCanvas(&rec, 200, 200), p)
+	var quads int
+	for _, a := range rec.Actions {
+		if _, ok := a.(*recorder.Fill); ok {
+			quads++
+		}
+	}
+	if want := 6 - 4; quads != want {
+		t.Errorf("unexpected number of filled quadrilaterals: got:%d want:%d", quads, want)
+	}
+
+	// The wireframe breaks each line at the NaN value,
+	// leaving single points in column 2 that are not
+	// drawn.
+	s.Palette = nil
+	s.LineStyle.Width = 1
+	rec.Actions = nil
+	s.Plot(draw.NewCanvas(&rec, 200, 200), p)
+	var lines int
+	for _, a := range rec.Actions {
+		if _, ok := a.(*recorder.Stroke); ok {
+			lines++
+		}
+	}
+	if want := 3 + 3; lines != want {
+		t.Errorf("unexpected number of wireframe lines: got:%d want:%d", lines, want)
+	}
+}
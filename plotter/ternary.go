@@ -0,0 +1,344 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/draw"
+)
+
+// ternaryHeight is the height of the ternary triangle
+// in data coordinates.
+var ternaryHeight = math.Sqrt(3) / 2
+
+// TernaryXY returns the cartesian location in the data coordinates
+// of a Ternary plot of the composition with components a, b and c.
+// The components are normalized by their sum, so they need not sum
+// to one. The pure compositions of a, b and c are located at the top,
+// bottom left and bottom right corners of the triangle, respectively.
+func TernaryXY(a, b, c float64) (x, y float64) {
+	s := a + b + c
+	return (a/2 + c) / s, ternaryHeight * a / s
+}
+
+// TernaryXYs implements the XYer interface, returning the cartesian
+// locations of the compositions given as x, y, z triples of an XYZer,
+// so that Scatter, Line and Polygon plotters can draw compositions in
+// a Ternary plot. The X, Y and Z values of the XYZer are the a, b and
+// c components.
+type TernaryXYs struct{ XYZer }
+
+// XY implements the XY method of the XYer interface.
+func (t TernaryXYs) XY(i int) (float64, float64) {
+	return TernaryXY(t.XYZ(i))
+}
+
+// TernaryAxis is an axis of a Ternary plot.
+type TernaryAxis struct {
+	// Label is the axis label text.
+	Label string
+
+	// LabelStyle is the style of the axis label text.
+	// The alignment and rotation of the label are set
+	// according to the side of the triangle it is on.
+	LabelStyle draw.TextStyle
+
+	// TickLabel is the style of the tick labels.
+	// The alignment of the tick labels is set
+	// according to the direction of the ticks.
+	TickLabel draw.TextStyle
+
+	// TickStyle is the style of the tick lines.
+	TickStyle draw.LineStyle
+
+	// TickLength is the length of a major tick mark.
+	// Minor tick marks are half of the length of major
+	// tick marks.
+	TickLength vg.Length
+
+	// Marker returns the tick marks of the axis
+	// for the range [0, 1].
+	Marker plot.Ticker
+
+	// GridStyle is the style of the grid lines
+	// of the axis at the major tick marks.
+	GridStyle draw.LineStyle
+}
+
+// Ternary implements the Plotter interface, drawing the triangular
+// frame, axes and grid of a ternary plot of three component
+// compositions. The plot and its data are drawn in cartesian data
+// coordinates given by TernaryXY, with the triangle spanning [0, 1] in
+// X and [0, √3/2] in Y, so the X and Y axes of the plot should be
+// hidden and the plot drawn with an aspect ratio of about 2:√3 for
+// the triangle to be equilateral.
+//
+// The A axis runs along the left side of the triangle with its grid
+// lines parallel to the bottom side. The B axis runs along the bottom
+// side with its grid lines parallel to the right side, and the C axis
+// runs along the right side with its grid lines parallel to the left
+// side.
+type Ternary struct {
+	// A, B and C are the axes of the three components.
+	A, B, C TernaryAxis
+
+	// LineStyle is the style of the triangular frame.
+	LineStyle draw.LineStyle
+}
+
+// NewTernary returns a Ternary with the given axis labels
+// using the default styles.
+func NewTernary(a, b, c string) (*Ternary, error) {
+	labelFont, err := vg.MakeFont(DefaultFont, vg.Points(12))
+	if err != nil {
+		return nil, err
+	}
+	tickFont, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+
+	axis := TernaryAxis{
+		LabelStyle: draw.TextStyle{Color: color.Black, Font: labelFont},
+		TickLabel:  draw.TextStyle{Color: color.Black, Font: tickFont},
+		TickStyle: draw.LineStyle{
+			Color: color.Black,
+			Width: vg.Points(0.5),
+		},
+		TickLength: vg.Points(6),
+		Marker:     plot.DefaultTicks{},
+		GridStyle: draw.LineStyle{
+			Color: color.Gray{Y: 196},
+			Width: vg.Points(0.25),
+		},
+	}
+	t := &Ternary{
+		A:         axis,
+		B:         axis,
+		C:         axis,
+		LineStyle: DefaultLineStyle,
+	}
+	t.A.Label, t.B.Label, t.C.Label = a, b, c
+	return t, nil
+}
+
+// ternaryEdge describes the placement of a ternary axis.
+type ternaryEdge struct {
+	// at returns the composition at the location t
+	// along the axis.
+	at func(t float64) (a, b, c float64)
+
+	// across returns the composition at the other end
+	// of the grid line through the location t.
+	across func(t float64) (a, b, c float64)
+}
+
+// edges returns the axes of the plot with their placements.
+func (t *Ternary) edges() ([]*TernaryAxis, []ternaryEdge) {
+	return []*TernaryAxis{&t.A, &t.B, &t.C}, []ternaryEdge{
+		{
+			at:     func(t float64) (a, b, c float64) { return t, 1 - t, 0 },
+			across: func(t float64) (a, b, c float64) { return t, 0, 1 - t },
+		},
+		{
+			at:     func(t float64) (a, b, c float64) { return 0, t, 1 - t },
+			across: func(t float64) (a, b, c float64) { return 1 - t, t, 0 },
+		},
+		{
+			at:     func(t float64) (a, b, c float64) { return 1 - t, 0, t },
+			across: func(t float64) (a, b, c float64) { return 0, 1 - t, t },
+		},
+	}
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (t *Ternary) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	tr := func(a, b, cc float64) vg.Point {
+		x, y := TernaryXY(a, b, cc)
+		return vg.Point{X: trX(x), Y: trY(y)}
+	}
+
+	axes, edges := t.edges()
+
+	for i, ax := range axes {
+		if ax.GridStyle.Width <= 0 {
+			continue
+		}
+		for _, tk := range ax.Marker.Ticks(0, 1) {
+			if tk.IsMinor() || tk.Value <= 0 || tk.Value >= 1 {
+				continue
+			}
+			from, to := tr(edges[i].at(tk.Value)), tr(edges[i].across(tk.Value))
+			c.StrokeLine2(ax.GridStyle, from.X, from.Y, to.X, to.Y)
+		}
+	}
+
+	frame := []vg.Point{tr(1, 0, 0), tr(0, 1, 0), tr(0, 0, 1), tr(1, 0, 0)}
+	c.StrokeLines(t.LineStyle, frame)
+
+	for i, ax := range axes {
+		e := edges[i]
+		dir := unit(tr(e.at(0.5)).Sub(tr(e.across(0.5))))
+		start, end := tr(e.at(0)), tr(e.at(1))
+		norm := outwardNormal(start, end, tr(1.0/3, 1.0/3, 1.0/3))
+
+		sty := ax.TickLabel
+		sty.XAlign = draw.XAlignment(-0.5 + 0.5*float64(dir.X))
+		sty.YAlign = draw.YAlignment(-0.5 + 0.5*float64(dir.Y))
+
+		var extent vg.Length
+		for _, tk := range ax.Marker.Ticks(0, 1) {
+			if tk.Value < 0 || tk.Value > 1 {
+				continue
+			}
+			pt := tr(e.at(tk.Value))
+			l := ax.TickLength
+			if tk.IsMinor() {
+				l /= 2
+			}
+			if ax.TickStyle.Width > 0 && l > 0 {
+				tip := pt.Add(dir.Scale(l))
+				c.StrokeLine2(ax.TickStyle, pt.X, pt.Y, tip.X, tip.Y)
+			}
+			if tk.IsMinor() {
+				continue
+			}
+			c.FillText(sty, pt.Add(dir.Scale(ax.TickLength+ax.TickLength/2)), tk.Label)
+			extent = maxLength(extent, ax.tickExtent(tk.Label, dir, norm))
+		}
+
+		if ax.Label == "" {
+			continue
+		}
+		lsty := ax.LabelStyle
+		lsty.Rotation = uprightAngle(end.Sub(start))
+		lsty.XAlign = draw.XCenter
+		up := vg.Point{X: vg.Length(-math.Sin(lsty.Rotation)), Y: vg.Length(math.Cos(lsty.Rotation))}
+		lsty.YAlign = draw.YBottom
+		if up.Dot(norm) < 0 {
+			lsty.YAlign = draw.YTop
+		}
+		mid := vg.Point{X: (start.X + end.X) / 2, Y: (start.Y + end.Y) / 2}
+		c.FillText(lsty, mid.Add(norm.Scale(extent+ax.TickLength/2)), ax.Label)
+	}
+}
+
+// tickExtent returns the distance from the axis, along the
+// outward normal norm, reached by the tick mark and tick label
+// drawn in the direction dir.
+func (ax *TernaryAxis) tickExtent(lbl string, dir, norm vg.Point) vg.Length {
+	w, h := ax.TickLabel.Width(lbl), ax.TickLabel.Height(lbl)
+	proj := vg.Length(math.Abs(float64(norm.X)))*w + vg.Length(math.Abs(float64(norm.Y)))*h
+	return (ax.TickLength+ax.TickLength/2)*dir.Dot(norm) + proj
+}
+
+// unit returns p scaled to unit length.
+func unit(p vg.Point) vg.Point {
+	l := math.Hypot(float64(p.X), float64(p.Y))
+	if l == 0 {
+		return p
+	}
+	return p.Scale(vg.Length(1 / l))
+}
+
+// outwardNormal returns the unit normal of the line from start
+// to end pointing away from the inside point.
+func outwardNormal(start, end, inside vg.Point) vg.Point {
+	d := unit(end.Sub(start))
+	n := vg.Point{X: -d.Y, Y: d.X}
+	if n.Dot(inside.Sub(start)) > 0 {
+		n = n.Scale(-1)
+	}
+	return n
+}
+
+// uprightAngle returns the angle of the direction d,
+// turned by half a turn if needed so that text drawn
+// at the angle is upright.
+func uprightAngle(d vg.Point) float64 {
+	theta := math.Atan2(float64(d.Y), float64(d.X))
+	switch {
+	case theta > math.Pi/2:
+		theta -= math.Pi
+	case theta <= -math.Pi/2:
+		theta += math.Pi
+	}
+	return theta
+}
+
+func maxLength(a, b vg.Length) vg.Length {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (t *Ternary) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return 0, 1, 0, ternaryHeight
+}
+
+// GlyphBoxes implements the GlyphBoxes method
+// of the plot.GlyphBoxer interface, returning a box
+// for each tick label and axis label, assuming that
+// the triangle is drawn equilateral.
+func (t *Ternary) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	tr := func(a, b, c float64) vg.Point {
+		x, y := TernaryXY(a, b, c)
+		return vg.Point{X: vg.Length(x), Y: vg.Length(y)}
+	}
+
+	var boxes []plot.GlyphBox
+	axes, edges := t.edges()
+	for i, ax := range axes {
+		e := edges[i]
+		dir := unit(tr(e.at(0.5)).Sub(tr(e.across(0.5))))
+		norm := outwardNormal(tr(e.at(0)), tr(e.at(1)), tr(1.0/3, 1.0/3, 1.0/3))
+		sty := ax.TickLabel
+		sty.XAlign = draw.XAlignment(-0.5 + 0.5*float64(dir.X))
+		sty.YAlign = draw.YAlignment(-0.5 + 0.5*float64(dir.Y))
+
+		var extent vg.Length
+		for _, tk := range ax.Marker.Ticks(0, 1) {
+			if tk.IsMinor() || tk.Value < 0 || tk.Value > 1 {
+				continue
+			}
+			x, y := TernaryXY(e.at(tk.Value))
+			r := sty.Rectangle(tk.Label)
+			off := dir.Scale(ax.TickLength + ax.TickLength/2)
+			boxes = append(boxes, plot.GlyphBox{
+				X: plt.X.Norm(x),
+				Y: plt.Y.Norm(y),
+				Rectangle: vg.Rectangle{
+					Min: r.Min.Add(off),
+					Max: r.Max.Add(off),
+				},
+			})
+			extent = maxLength(extent, ax.tickExtent(tk.Label, dir, norm))
+		}
+
+		if ax.Label == "" {
+			continue
+		}
+		x, y := TernaryXY(e.at(0.5))
+		w := ax.LabelStyle.Height(ax.Label)
+		off := norm.Scale(extent + ax.TickLength/2 + w/2)
+		boxes = append(boxes, plot.GlyphBox{
+			X: plt.X.Norm(x),
+			Y: plt.Y.Norm(y),
+			Rectangle: vg.Rectangle{
+				Min: off.Sub(vg.Point{X: w / 2, Y: w / 2}),
+				Max: off.Add(vg.Point{X: w / 2, Y: w / 2}),
+			},
+		})
+	}
+	return boxes
+}
@@ -0,0 +1,111 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter_test
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/cmpimg"
+	"github.com/gshk/plot/plotter"
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/draw"
+	"github.com/gshk/plot/vg/vgimg"
+)
+
+// ExampleTernary draws soil compositions in a ternary plot.
+func ExampleTernary() {
+	tern, err := plotter.NewTernary("Sand", "Silt", "Clay")
+	if err != nil {
+		log.Panic(err)
+	}
+
+	samples := plotter.XYZs{
+		{X: 0.6, Y: 0.2, Z: 0.2},
+		{X: 0.1, Y: 0.8, Z: 0.1},
+		{X: 0.2, Y: 0.2, Z: 0.6},
+		{X: 1, Y: 1, Z: 1}, // Compositions need not be normalized.
+	}
+	sc, err := plotter.NewScatter(plotter.TernaryXYs{XYZer: samples})
+	if err != nil {
+		log.Panic(err)
+	}
+	sc.Color = color.RGBA{R: 255, A: 255}
+	sc.Radius = vg.Points(3)
+
+	region, err := plotter.NewPolygon(plotter.TernaryXYs{XYZer: plotter.XYZs{
+		{X: 0.5, Y: 0.5},
+		{X: 0.5, Z: 0.5},
+		{X: 0.8, Y: 0.1, Z: 0.1},
+	}})
+	if err != nil {
+		log.Panic(err)
+	}
+	region.Color = color.RGBA{B: 80, A: 80}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.HideAxes()
+	p.Add(tern, region, sc)
+
+	err = p.Save(300, 270, "testdata/ternary.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestTernary(t *testing.T) {
+	cmpimg.CheckPlot(ExampleTernary, t, "ternary.png")
+}
+
+func TestTernaryXY(t *testing.T) {
+	h := math.Sqrt(3) / 2
+	for _, test := range []struct {
+		a, b, c float64
+		x, y    float64
+	}{
+		{a: 1, x: 0.5, y: h},
+		{b: 1, x: 0, y: 0},
+		{c: 1, x: 1, y: 0},
+		{a: 1, b: 1, c: 1, x: 0.5, y: h / 3},
+		{a: 2, b: 2, x: 0.25, y: h / 2},
+	} {
+		x, y := plotter.TernaryXY(test.a, test.b, test.c)
+		if math.Abs(x-test.x) > 1e-12 || math.Abs(y-test.y) > 1e-12 {
+			t.Errorf("unexpected location for (%v, %v, %v): got:(%v, %v) want:(%v, %v)",
+				test.a, test.b, test.c, x, y, test.x, test.y)
+		}
+	}
+
+	xys := plotter.TernaryXYs{XYZer: plotter.XYZs{{X: 0, Y: 0, Z: 3}}}
+	if x, y := xys.XY(0); x != 1 || y != 0 {
+		t.Errorf("unexpected location from TernaryXYs: got:(%v, %v) want:(1, 0)", x, y)
+	}
+}
+
+func TestTernaryDraw(t *testing.T) {
+	tern, err := plotter.NewTernary("A", "B", "C")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.HideAxes()
+	p.Add(tern)
+	if xmin, xmax, ymin, ymax := tern.DataRange(); xmin != 0 || xmax != 1 || ymin != 0 || ymax != math.Sqrt(3)/2 {
+		t.Errorf("unexpected data range: got:%v %v %v %v", xmin, xmax, ymin, ymax)
+	}
+	if n := len(tern.GlyphBoxes(p)); n == 0 {
+		t.Error("expected glyph boxes for tick labels")
+	}
+	p.Draw(draw.New(vgimg.New(300, 270)))
+}
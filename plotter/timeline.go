@@ -0,0 +1,299 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/draw"
+)
+
+// A TimelineItem is an interval or milestone in a lane of a Timeline.
+type TimelineItem struct {
+	// Lane is the name of the lane holding the item.
+	Lane string
+
+	// Label is the text drawn inside the bar of the item.
+	// Labels that do not fit inside their bar are not drawn.
+	Label string
+
+	// Start and End are the X locations of the beginning
+	// and end of the item. When used with plot.TimeTicks
+	// these are the times of the item in the units
+	// understood by the TimeTicks Time function.
+	// End must not be less than Start.
+	Start, End float64
+
+	// Group specifies the group that an item belongs
+	// to. It is used in assigning styles to groups.
+	Group string
+
+	// Milestone specifies that the item is drawn as
+	// a marker at Start rather than as a bar. The End
+	// of milestones is ignored.
+	Milestone bool
+
+	// DependsOn holds the indices of the items that
+	// this item depends on. An arrow is drawn from
+	// the end of each of those items to the start of
+	// this item.
+	DependsOn []int
+}
+
+// Timeline implements the Plotter interface, drawing a Gantt
+// chart of items arranged in named horizontal lanes. The lane
+// with index i is centered at the Y location i, so the lanes
+// can be labeled by calling the NominalY method of the plot
+// with the Lanes field.
+type Timeline struct {
+	// Items are the items of the timeline.
+	Items []TimelineItem
+
+	// Lanes are the names of the lanes in order from
+	// the bottom of the plot. NewTimeline sets Lanes
+	// to the lane names of the items in order of
+	// first appearance.
+	Lanes []string
+
+	// Width is the thickness of the item bars.
+	Width vg.Length
+
+	// Color specifies the default fill color of the
+	// item bars and milestones.
+	Color color.Color
+
+	// LineStyle specifies the default style of the
+	// outline of the item bars.
+	LineStyle draw.LineStyle
+
+	// GroupStyle is a function that specifies the fill
+	// color and outline style of items based on their
+	// group name. The default function uses the default
+	// Color and LineStyle specified above for all groups.
+	GroupStyle func(group string) (color.Color, draw.LineStyle)
+
+	// MilestoneStyle is the style of the milestone markers.
+	// The color of the markers is taken from GroupStyle.
+	MilestoneStyle draw.GlyphStyle
+
+	// ArrowStyle is the line style of the dependency arrows.
+	ArrowStyle draw.LineStyle
+
+	// ArrowHead is the length of the dependency arrow heads.
+	ArrowHead vg.Length
+
+	// TextStyle is the style of the item labels.
+	TextStyle draw.TextStyle
+}
+
+// NewTimeline returns a Timeline holding the given items. An error
+// is returned if any item has a non-finite or reversed interval, or
+// a dependency that is not the index of another item.
+func NewTimeline(items ...TimelineItem) (*Timeline, error) {
+	if len(items) == 0 {
+		return nil, ErrNoData
+	}
+	t := &Timeline{
+		Items: make([]TimelineItem, len(items)),
+	}
+	seen := make(map[string]bool)
+	for i, it := range items {
+		if it.Milestone {
+			it.End = it.Start
+		}
+		if err := CheckFinite(it.Start, it.End); err != nil {
+			return nil, err
+		}
+		if it.End < it.Start {
+			return nil, fmt.Errorf("plotter: item %d End (%g) < Start (%g)", i, it.End, it.Start)
+		}
+		for _, d := range it.DependsOn {
+			if d < 0 || d >= len(items) || d == i {
+				return nil, fmt.Errorf("plotter: item %d has invalid dependency %d", i, d)
+			}
+		}
+		it.DependsOn = append([]int(nil), it.DependsOn...)
+		t.Items[i] = it
+
+		if !seen[it.Lane] {
+			seen[it.Lane] = true
+			t.Lanes = append(t.Lanes, it.Lane)
+		}
+	}
+
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+
+	t.Width = vg.Points(12)
+	t.Color = color.Gray{Y: 160}
+	t.LineStyle = DefaultLineStyle
+	t.GroupStyle = func(string) (color.Color, draw.LineStyle) {
+		return t.Color, t.LineStyle
+	}
+	t.MilestoneStyle = draw.GlyphStyle{
+		Radius: vg.Points(5),
+		Shape:  draw.PyramidGlyph{},
+	}
+	t.ArrowStyle = DefaultLineStyle
+	t.ArrowHead = vg.Points(4)
+	t.TextStyle = draw.TextStyle{
+		Font:   fnt,
+		XAlign: draw.XCenter,
+		YAlign: draw.YCenter,
+	}
+	return t, nil
+}
+
+// laneIndices returns the Y locations of the
+// lanes of the timeline, keyed by lane name.
+func (t *Timeline) laneIndices() map[string]float64 {
+	lanes := make(map[string]float64, len(t.Lanes))
+	for i, l := range t.Lanes {
+		lanes[l] = float64(i)
+	}
+	for _, it := range t.Items {
+		if _, ok := lanes[it.Lane]; !ok {
+			panic(fmt.Sprintf("plotter: timeline item in unknown lane %q", it.Lane))
+		}
+	}
+	return lanes
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (t *Timeline) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	lanes := t.laneIndices()
+
+	for _, it := range t.Items {
+		if it.Milestone {
+			continue
+		}
+		y := trY(lanes[it.Lane])
+		if !c.ContainsY(y) {
+			continue
+		}
+		r := vg.Rectangle{
+			Min: vg.Point{X: trX(it.Start), Y: y - t.Width/2},
+			Max: vg.Point{X: trX(it.End), Y: y + t.Width/2},
+		}
+		// Bars are clipped horizontally only so that lanes
+		// at the edge of the data area are drawn whole
+		// within the padding given by GlyphBoxes.
+		pts := c.ClipPolygonX([]vg.Point{
+			r.Min, {X: r.Max.X, Y: r.Min.Y}, r.Max, {X: r.Min.X, Y: r.Max.Y},
+		})
+		col, ls := t.GroupStyle(it.Group)
+		if col != nil {
+			c.FillPolygon(col, pts)
+		}
+		if len(pts) > 0 {
+			pts = append(pts, pts[0])
+			c.StrokeLines(ls, c.ClipLinesX(pts)...)
+		}
+
+		if it.Label == "" {
+			continue
+		}
+		w, h := t.TextStyle.Width(it.Label), t.TextStyle.Height(it.Label)
+		if size := r.Size(); w > size.X || h > size.Y {
+			continue
+		}
+		pt := vg.Point{
+			X: (r.Min.X+r.Max.X)/2 - vg.Length(t.TextStyle.XAlign+0.5)*w,
+			Y: y - vg.Length(t.TextStyle.YAlign+0.5)*h,
+		}
+		if c.ContainsX(pt.X) {
+			c.FillText(t.TextStyle, pt, it.Label)
+		}
+	}
+
+	for i, it := range t.Items {
+		for _, d := range it.DependsOn {
+			t.drawArrow(c, trX, trY, lanes, t.Items[d], t.Items[i])
+		}
+	}
+
+	for _, it := range t.Items {
+		if !it.Milestone {
+			continue
+		}
+		sty := t.MilestoneStyle
+		sty.Color, _ = t.GroupStyle(it.Group)
+		c.DrawGlyph(sty, vg.Point{X: trX(it.Start), Y: trY(lanes[it.Lane])})
+	}
+}
+
+// drawArrow draws a dependency arrow from the end of the item from
+// to the start of the item to. When to starts after from ends the
+// arrow is an elbow connector, otherwise it is a straight line.
+func (t *Timeline) drawArrow(c draw.Canvas, trX, trY func(float64) vg.Length, lanes map[string]float64, from, to TimelineItem) {
+	p0 := vg.Point{X: trX(from.End), Y: trY(lanes[from.Lane])}
+	p1 := vg.Point{X: trX(to.Start), Y: trY(lanes[to.Lane])}
+
+	// Arrive vertically just inside the start of the target,
+	// ending at the edge of its bar or marker.
+	x, half := p1.X, t.MilestoneStyle.Radius
+	if !to.Milestone {
+		x = vg.Length(math.Min(float64(p1.X+t.ArrowHead), float64(p1.X+trX(to.End))/2))
+		half = t.Width / 2
+	}
+
+	var pts []vg.Point
+	switch {
+	case x > p0.X && p1.Y != p0.Y:
+		end := p1.Y + half
+		if p1.Y > p0.Y {
+			end = p1.Y - half
+		}
+		pts = []vg.Point{p0, {X: x, Y: p0.Y}, {X: x, Y: end}}
+	default:
+		pts = []vg.Point{p0, p1}
+	}
+
+	c.StrokeLines(t.ArrowStyle, c.ClipLinesXY(pts)...)
+
+	// Draw the arrow head along the last segment.
+	tip, tail := pts[len(pts)-1], pts[len(pts)-2]
+	if c.Contains(tip) {
+		FilledArrowHead.draw(c, t.ArrowStyle, tip, tip.Sub(tail), t.ArrowHead)
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (t *Timeline) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	for _, it := range t.Items {
+		xmin = math.Min(xmin, it.Start)
+		xmax = math.Max(xmax, it.End)
+	}
+	return xmin, xmax, 0, float64(len(t.Lanes) - 1)
+}
+
+// GlyphBoxes implements the GlyphBoxes method
+// of the plot.GlyphBoxer interface.
+func (t *Timeline) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	lanes := t.laneIndices()
+	boxes := make([]plot.GlyphBox, len(t.Items))
+	for i, it := range t.Items {
+		boxes[i].X = plt.X.Norm(it.Start)
+		boxes[i].Y = plt.Y.Norm(lanes[it.Lane])
+		if it.Milestone {
+			boxes[i].Rectangle = t.MilestoneStyle.Rectangle()
+			continue
+		}
+		boxes[i].Rectangle = vg.Rectangle{
+			Min: vg.Point{Y: -t.Width / 2},
+			Max: vg.Point{Y: t.Width / 2},
+		}
+	}
+	return boxes
+}
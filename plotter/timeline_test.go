@@ -0,0 +1,96 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter_test
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/cmpimg"
+	"github.com/gshk/plot/plotter"
+	"github.com/gshk/plot/vg/draw"
+)
+
+// ExampleTimeline draws a job schedule with dependencies
+// between the jobs and a milestone marking the release.
+func ExampleTimeline() {
+	t0 := float64(time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC).Unix())
+	const hour = 3600
+
+	tl, err := plotter.NewTimeline(
+		plotter.TimelineItem{Lane: "build", Label: "compile", Start: t0, End: t0 + 3*hour, Group: "ci"},
+		plotter.TimelineItem{Lane: "test", Label: "unit", Start: t0 + 4*hour, End: t0 + 8*hour, Group: "ci", DependsOn: []int{0}},
+		plotter.TimelineItem{Lane: "test", Start: t0 + 2*hour, End: t0 + 2.5*hour, Group: "incident"},
+		plotter.TimelineItem{Lane: "deploy", Label: "rollout", Start: t0 + 9*hour, End: t0 + 12*hour, Group: "ops", DependsOn: []int{1}},
+		plotter.TimelineItem{Lane: "deploy", Start: t0 + 13*hour, Milestone: true, Group: "ops", DependsOn: []int{3}},
+	)
+	if err != nil {
+		log.Panic(err)
+	}
+	tl.GroupStyle = func(group string) (color.Color, draw.LineStyle) {
+		switch group {
+		case "ci":
+			return color.RGBA{R: 120, G: 160, B: 220, A: 255}, tl.LineStyle
+		case "ops":
+			return color.RGBA{G: 160, B: 80, A: 255}, tl.LineStyle
+		default:
+			return color.RGBA{R: 220, A: 255}, tl.LineStyle
+		}
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Release schedule"
+	p.X.Tick.Marker = plot.TimeTicks{Format: "15:04"}
+	p.Add(tl)
+	p.NominalY(tl.Lanes...)
+
+	err = p.Save(400, 200, "testdata/timeline.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	cmpimg.CheckPlot(ExampleTimeline, t, "timeline.png")
+}
+
+func TestNewTimeline(t *testing.T) {
+	tl, err := plotter.NewTimeline(
+		plotter.TimelineItem{Lane: "b", Start: 1, End: 2},
+		plotter.TimelineItem{Lane: "a", Start: 0, End: 5, DependsOn: []int{0}},
+		plotter.TimelineItem{Lane: "b", Start: 7, End: 1, Milestone: true},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"b", "a"}; !reflect.DeepEqual(tl.Lanes, want) {
+		t.Errorf("unexpected lanes: got:%q want:%q", tl.Lanes, want)
+	}
+	xmin, xmax, ymin, ymax := tl.DataRange()
+	if xmin != 0 || xmax != 7 || ymin != 0 || ymax != 1 {
+		t.Errorf("unexpected data range: got:%v %v %v %v want:0 7 0 1", xmin, xmax, ymin, ymax)
+	}
+
+	for _, items := range [][]plotter.TimelineItem{
+		nil,
+		{{Start: 1, End: 0}},
+		{{Start: math.NaN(), End: 0}},
+		{{Start: 0, End: math.Inf(1)}},
+		{{Start: 0, End: 1, DependsOn: []int{0}}},
+		{{Start: 0, End: 1, DependsOn: []int{1}}},
+	} {
+		if _, err := plotter.NewTimeline(items...); err == nil {
+			t.Errorf("expected error for items %+v", items)
+		}
+	}
+}
@@ -0,0 +1,448 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/palette"
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/draw"
+)
+
+// TreeNode is a labeled, weighted node of a tree
+// displayed by a Treemap.
+type TreeNode struct {
+	// Label is the text drawn inside the cell
+	// representing the node.
+	Label string
+
+	// Weight is the weight of a leaf node. The weight
+	// of a node with children is the sum of the weights
+	// of its children and Weight is ignored.
+	Weight float64
+
+	// Value is the value used to color the node
+	// when the Treemap is colored by value.
+	Value float64
+
+	// Children are the child nodes of the node.
+	Children []*TreeNode
+}
+
+// weight returns the total weight of the node.
+func (n *TreeNode) weight() float64 {
+	if len(n.Children) == 0 {
+		return n.Weight
+	}
+	var w float64
+	for _, c := range n.Children {
+		w += c.weight()
+	}
+	return w
+}
+
+// check returns an error if the node or any of its
+// descendants has an invalid weight.
+func (n *TreeNode) check() error {
+	if n == nil {
+		return errors.New("plotter: nil tree node")
+	}
+	if len(n.Children) == 0 {
+		if err := CheckFinite(n.Weight); err != nil {
+			return err
+		}
+		if n.Weight < 0 {
+			return errors.New("plotter: invalid tree node weight")
+		}
+		return nil
+	}
+	for _, c := range n.Children {
+		if err := c.check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TreemapLayout specifies the algorithm used to
+// lay out the cells of a Treemap.
+type TreemapLayout int
+
+const (
+	// Squarified lays out cells using the squarified
+	// algorithm of Bruls, Huizing and van Wijk, which
+	// keeps the aspect ratio of the cells close to one.
+	Squarified TreemapLayout = iota
+
+	// SliceAndDice lays out cells in the order of the
+	// children, alternating between horizontal and vertical
+	// slicing at each level of the tree.
+	SliceAndDice
+)
+
+// TreemapColoring specifies the quantity used to
+// choose the fill color of Treemap cells.
+type TreemapColoring int
+
+const (
+	// ColorByDepth colors cells by their depth in the tree.
+	ColorByDepth TreemapColoring = iota
+
+	// ColorByValue colors cells by the Value of their node.
+	ColorByValue
+)
+
+// Treemap implements the Plotter interface, drawing a
+// hierarchical part-of-whole view of a tree of weighted
+// nodes. The area of each cell is proportional to the
+// weight of its node. The treemap fills the unit square
+// of data coordinates.
+type Treemap struct {
+	// Root is the root node of the tree.
+	Root *TreeNode
+
+	// Layout is the algorithm used to lay out the cells.
+	Layout TreemapLayout
+
+	// ColorMap is used to fill the cells. If ColorMap is
+	// nil, cells are filled with Color.
+	ColorMap palette.ColorMap
+
+	// Coloring specifies whether the ColorMap is indexed
+	// by node depth or by node value. When coloring by
+	// depth, the depths of the tree are spread uniformly
+	// across the range of the ColorMap. When coloring by
+	// value, values outside the range of the ColorMap
+	// are clamped to it.
+	Coloring TreemapColoring
+
+	// Color is the fill color of the cells when
+	// ColorMap is nil. If Color is also nil, the
+	// cells are not filled.
+	Color color.Color
+
+	// LineStyle is the style of the outline of the cells.
+	LineStyle draw.LineStyle
+
+	// TextStyle is the style of the cell labels. Labels
+	// that do not fit inside their cells are not drawn.
+	TextStyle draw.TextStyle
+
+	// Padding is the space between the edge of a
+	// cell and the cells of its children. Cells of
+	// labeled nodes with children also leave room for
+	// a header holding the label.
+	Padding vg.Length
+}
+
+// NewTreemap returns a Treemap for the tree with the given
+// root, using the squarified layout, the default line style
+// and the default font. An error is returned if any weight
+// in the tree is negative, NaN or infinite, or if the total
+// weight of the tree is zero.
+func NewTreemap(root *TreeNode) (*Treemap, error) {
+	if err := root.check(); err != nil {
+		return nil, err
+	}
+	if root.weight() == 0 {
+		return nil, ErrNoData
+	}
+
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Treemap{
+		Root:      root,
+		LineStyle: DefaultLineStyle,
+		TextStyle: draw.TextStyle{
+			Font:   fnt,
+			XAlign: draw.XCenter,
+			YAlign: draw.YCenter,
+		},
+		Padding: vg.Points(2),
+	}, nil
+}
+
+// treemapCell is a laid out node of a Treemap.
+type treemapCell struct {
+	node  *TreeNode
+	depth int
+	rect  vg.Rectangle
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (t *Treemap) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	r := vg.Rectangle{
+		Min: vg.Point{X: trX(0), Y: trY(0)},
+		Max: vg.Point{X: trX(1), Y: trY(1)},
+	}
+	cells := t.layout(r)
+
+	maxDepth := 0
+	for _, cell := range cells {
+		if cell.depth > maxDepth {
+			maxDepth = cell.depth
+		}
+	}
+
+	for _, cell := range cells {
+		if col := t.color(cell, maxDepth); col != nil {
+			c.SetColor(col)
+			c.Fill(cell.rect.Path())
+		}
+		if t.LineStyle.Width > 0 {
+			c.SetLineStyle(t.LineStyle)
+			c.Stroke(cell.rect.Path())
+		}
+	}
+
+	for _, cell := range cells {
+		t.drawLabel(c, cell)
+	}
+}
+
+// color returns the fill color of the cell.
+func (t *Treemap) color(cell treemapCell, maxDepth int) color.Color {
+	if t.ColorMap == nil {
+		return t.Color
+	}
+	min, max := t.ColorMap.Min(), t.ColorMap.Max()
+	var v float64
+	switch t.Coloring {
+	case ColorByDepth:
+		v = min
+		if maxDepth > 0 {
+			v += (max - min) * float64(cell.depth) / float64(maxDepth)
+		}
+	case ColorByValue:
+		v = math.Max(min, math.Min(max, cell.node.Value))
+	default:
+		panic("plotter: unknown treemap coloring")
+	}
+	col, err := t.ColorMap.At(v)
+	if err != nil {
+		panic(err)
+	}
+	return col
+}
+
+// drawLabel draws the label of the cell if it fits. Leaf labels
+// are centered in the cell. Labels of nodes with children are drawn
+// in a header at the top of the cell.
+func (t *Treemap) drawLabel(c draw.Canvas, cell treemapCell) {
+	lbl := cell.node.Label
+	if lbl == "" {
+		return
+	}
+	sty := t.TextStyle
+	w, h := sty.Width(lbl), sty.Height(lbl)
+	size := cell.rect.Size()
+
+	if len(cell.node.Children) == 0 {
+		if w > size.X-2*t.Padding || h > size.Y-2*t.Padding {
+			return
+		}
+		c.FillText(sty, vg.Point{
+			X: cell.rect.Min.X + size.X/2 - vg.Length(sty.XAlign+0.5)*w,
+			Y: cell.rect.Min.Y + size.Y/2 - vg.Length(sty.YAlign+0.5)*h,
+		}, lbl)
+		return
+	}
+
+	if w > size.X-2*t.Padding || h > size.Y-2*t.Padding {
+		return
+	}
+	sty.XAlign = draw.XLeft
+	sty.YAlign = draw.YTop
+	c.FillText(sty, vg.Point{
+		X: cell.rect.Min.X + t.Padding,
+		Y: cell.rect.Max.Y - t.Padding,
+	}, lbl)
+}
+
+// layout returns the cells of the tree laid out within r in
+// drawing order, parents before their children.
+func (t *Treemap) layout(r vg.Rectangle) []treemapCell {
+	var cells []treemapCell
+	var walk func(n *TreeNode, depth int, r vg.Rectangle)
+	walk = func(n *TreeNode, depth int, r vg.Rectangle) {
+		cells = append(cells, treemapCell{node: n, depth: depth, rect: r})
+		if len(n.Children) == 0 {
+			return
+		}
+
+		// Leave room for a header holding the label
+		// of the node above its children.
+		var header vg.Length
+		if n.Label != "" {
+			header = t.TextStyle.Height(n.Label) + t.Padding
+		}
+		inner := vg.Rectangle{
+			Min: vg.Point{X: r.Min.X + t.Padding, Y: r.Min.Y + t.Padding},
+			Max: vg.Point{X: r.Max.X - t.Padding, Y: r.Max.Y - t.Padding - header},
+		}
+		if inner.Min.X >= inner.Max.X || inner.Min.Y >= inner.Max.Y {
+			return
+		}
+
+		var children []*TreeNode
+		var weights []float64
+		for _, c := range n.Children {
+			if w := c.weight(); w > 0 {
+				children = append(children, c)
+				weights = append(weights, w)
+			}
+		}
+
+		var rects []vg.Rectangle
+		switch t.Layout {
+		case Squarified:
+			rects = squarify(weights, inner)
+		case SliceAndDice:
+			rects = sliceAndDice(weights, inner, depth%2 == 0)
+		default:
+			panic("plotter: unknown treemap layout")
+		}
+		for i, c := range children {
+			walk(c, depth+1, rects[i])
+		}
+	}
+	walk(t.Root, 0, r)
+	return cells
+}
+
+// sliceAndDice returns rectangles partitioning r with areas
+// proportional to weights, in order. The rectangles are
+// arranged left to right if horizontal is true and top to
+// bottom otherwise.
+func sliceAndDice(weights []float64, r vg.Rectangle, horizontal bool) []vg.Rectangle {
+	var sum float64
+	for _, w := range weights {
+		sum += w
+	}
+	size := r.Size()
+	rects := make([]vg.Rectangle, len(weights))
+	var off float64
+	for i, w := range weights {
+		f0, f1 := off/sum, (off+w)/sum
+		off += w
+		if horizontal {
+			rects[i] = vg.Rectangle{
+				Min: vg.Point{X: r.Min.X + vg.Length(f0)*size.X, Y: r.Min.Y},
+				Max: vg.Point{X: r.Min.X + vg.Length(f1)*size.X, Y: r.Max.Y},
+			}
+		} else {
+			rects[i] = vg.Rectangle{
+				Min: vg.Point{X: r.Min.X, Y: r.Max.Y - vg.Length(f1)*size.Y},
+				Max: vg.Point{X: r.Max.X, Y: r.Max.Y - vg.Length(f0)*size.Y},
+			}
+		}
+	}
+	return rects
+}
+
+// squarify returns rectangles partitioning r with areas
+// proportional to weights, using the squarified treemap
+// algorithm. The returned rectangles are in the order of
+// weights.
+func squarify(weights []float64, r vg.Rectangle) []vg.Rectangle {
+	rects := make([]vg.Rectangle, len(weights))
+	if len(weights) == 0 {
+		return rects
+	}
+
+	// Lay out the largest weights first.
+	idx := make([]int, len(weights))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return weights[idx[i]] > weights[idx[j]] })
+
+	x, y := float64(r.Min.X), float64(r.Min.Y)
+	width, height := float64(r.Max.X-r.Min.X), float64(r.Max.Y-r.Min.Y)
+	var sum float64
+	for _, w := range weights {
+		sum += w
+	}
+	areas := make([]float64, len(weights))
+	for i, w := range weights {
+		areas[i] = w / sum * width * height
+	}
+
+	for i := 0; i < len(idx); {
+		// Grow the row while the worst aspect
+		// ratio within it improves.
+		side := math.Min(width, height)
+		j := i + 1
+		rowSum := areas[idx[i]]
+		for ; j < len(idx); j++ {
+			next := rowSum + areas[idx[j]]
+			if worstAspect(next, areas[idx[j]], areas[idx[i]], side) > worstAspect(rowSum, areas[idx[j-1]], areas[idx[i]], side) {
+				break
+			}
+			rowSum = next
+		}
+
+		if width >= height {
+			// Place the row as a column on the left.
+			colWidth := rowSum / height
+			if j == len(idx) {
+				colWidth = width
+			}
+			top := y + height
+			for _, k := range idx[i:j] {
+				h := areas[k] / rowSum * height
+				rects[k] = vg.Rectangle{
+					Min: vg.Point{X: vg.Length(x), Y: vg.Length(top - h)},
+					Max: vg.Point{X: vg.Length(x + colWidth), Y: vg.Length(top)},
+				}
+				top -= h
+			}
+			x += colWidth
+			width -= colWidth
+		} else {
+			// Place the row along the top.
+			rowHeight := rowSum / width
+			if j == len(idx) {
+				rowHeight = height
+			}
+			left := x
+			for _, k := range idx[i:j] {
+				w := areas[k] / rowSum * width
+				rects[k] = vg.Rectangle{
+					Min: vg.Point{X: vg.Length(left), Y: vg.Length(y + height - rowHeight)},
+					Max: vg.Point{X: vg.Length(left + w), Y: vg.Length(y + height)},
+				}
+				left += w
+			}
+			height -= rowHeight
+		}
+		i = j
+	}
+	return rects
+}
+
+// worstAspect returns the worst aspect ratio of a row of
+// cells with total area sum, smallest cell area min and
+// largest cell area max laid out along a side of length side.
+func worstAspect(sum, min, max, side float64) float64 {
+	s2 := side * side
+	sum2 := sum * sum
+	return math.Max(s2*max/sum2, sum2/(s2*min))
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (t *Treemap) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return 0, 1, 0, 1
+}
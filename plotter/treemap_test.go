@@ -0,0 +1,123 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gshk/plot/vg"
+)
+
+func rectArea(r vg.Rectangle) float64 {
+	s := r.Size()
+	return float64(s.X * s.Y)
+}
+
+func rectsOverlap(a, b vg.Rectangle) bool {
+	const tol = 1e-9
+	return a.Min.X < b.Max.X-tol && b.Min.X < a.Max.X-tol &&
+		a.Min.Y < b.Max.Y-tol && b.Min.Y < a.Max.Y-tol
+}
+
+func TestSquarify(t *testing.T) {
+	// The example from Bruls, Huizing and van Wijk.
+	weights := []float64{6, 6, 4, 3, 2, 2, 1}
+	r := vg.Rectangle{Max: vg.Point{X: 6, Y: 4}}
+	got := squarify(weights, r)
+
+	for i, rect := range got {
+		if math.Abs(rectArea(rect)-weights[i]) > 1e-9 {
+			t.Errorf("unexpected area for cell %d: got:%v want:%v", i, rectArea(rect), weights[i])
+		}
+		if rect.Min.X < r.Min.X || rect.Min.Y < r.Min.Y || rect.Max.X > r.Max.X+1e-9 || rect.Max.Y > r.Max.Y+1e-9 {
+			t.Errorf("cell %d outside bounds: %+v", i, rect)
+		}
+		for j := i + 1; j < len(got); j++ {
+			if rectsOverlap(rect, got[j]) {
+				t.Errorf("cells %d and %d overlap: %+v %+v", i, j, rect, got[j])
+			}
+		}
+	}
+
+	// The first two cells form the first column
+	// and are each 3×2.
+	for i := 0; i < 2; i++ {
+		if s := got[i].Size(); math.Abs(float64(s.X)-3) > 1e-9 || math.Abs(float64(s.Y)-2) > 1e-9 {
+			t.Errorf("unexpected size for cell %d: got:%+v want:{X:3 Y:2}", i, s)
+		}
+	}
+}
+
+func TestSliceAndDice(t *testing.T) {
+	weights := []float64{1, 2, 1}
+	r := vg.Rectangle{Max: vg.Point{X: 4, Y: 2}}
+	for _, horizontal := range []bool{true, false} {
+		got := sliceAndDice(weights, r, horizontal)
+		for i, rect := range got {
+			if math.Abs(rectArea(rect)-weights[i]*2) > 1e-9 {
+				t.Errorf("unexpected area for cell %d horizontal=%t: got:%v want:%v", i, horizontal, rectArea(rect), weights[i]*2)
+			}
+		}
+		if horizontal && got[1].Min.X != 1 {
+			t.Errorf("unexpected position for horizontal slice: got:%v want:1", got[1].Min.X)
+		}
+		if !horizontal && got[0].Max.Y != 2 {
+			t.Errorf("unexpected position for vertical slice: got:%v want:2", got[0].Max.Y)
+		}
+	}
+}
+
+func TestTreemapLayout(t *testing.T) {
+	root := &TreeNode{
+		Children: []*TreeNode{
+			{Label: "a", Weight: 3},
+			{Children: []*TreeNode{
+				{Label: "b", Weight: 1},
+				{Label: "c", Weight: 1},
+				{Label: "empty"},
+			}},
+		},
+	}
+	tm, err := NewTreemap(root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tm.Padding = 0
+
+	for _, layout := range []TreemapLayout{Squarified, SliceAndDice} {
+		tm.Layout = layout
+		cells := tm.layout(vg.Rectangle{Max: vg.Point{X: 50, Y: 10}})
+		if len(cells) != 5 {
+			t.Fatalf("unexpected number of cells for layout %d: got:%d want:5", layout, len(cells))
+		}
+		want := []struct {
+			depth int
+			area  float64
+		}{{0, 500}, {1, 300}, {1, 200}, {2, 100}, {2, 100}}
+		for i, c := range cells {
+			if c.depth != want[i].depth {
+				t.Errorf("unexpected depth for cell %d: got:%d want:%d", i, c.depth, want[i].depth)
+			}
+			if math.Abs(rectArea(c.rect)-want[i].area) > 1e-9 {
+				t.Errorf("unexpected area for cell %d: got:%v want:%v", i, rectArea(c.rect), want[i].area)
+			}
+		}
+	}
+}
+
+func TestNewTreemapErrors(t *testing.T) {
+	for _, root := range []*TreeNode{
+		nil,
+		{Weight: -1},
+		{Weight: math.NaN()},
+		{Children: []*TreeNode{{Weight: math.Inf(1)}}},
+		{Children: []*TreeNode{{Weight: 0}}},
+	} {
+		if _, err := NewTreemap(root); err == nil {
+			t.Errorf("expected error for tree %+v", root)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/palette"
+	"github.com/gshk/plot/plotter"
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/draw"
+)
+
+// CalendarHeatMap is a calendar heat map of daily values split into
+// panels for each year, stacked from the earliest year at the top.
+type CalendarHeatMap struct {
+	// Plots are the panels of the years, titled
+	// with the year and labeled with the months
+	// and the days of the week.
+	Plots []*plot.Plot
+
+	// Calendars are the calendars drawn in the
+	// panels. Each calendar spans the whole of its
+	// year and all share the same ColorMap.
+	Calendars []*plotter.Calendar
+
+	// Padding is the vertical space between panels.
+	Padding vg.Length
+}
+
+// NewCalendarHeatMap returns a CalendarHeatMap of the values with a
+// panel for each year from the earliest value to the latest. The range
+// of cm is set to the range of all of the values.
+func NewCalendarHeatMap(vs []plotter.DatedValue, cm palette.ColorMap) (*CalendarHeatMap, error) {
+	all, err := plotter.NewCalendar(vs, cm)
+	if err != nil {
+		return nil, err
+	}
+
+	first, last := all.Start.Year(), all.End.Year()
+	years := make([][]plotter.DatedValue, last-first+1)
+	for _, v := range all.Values {
+		i := v.Date.Year() - first
+		years[i] = append(years[i], v)
+	}
+
+	h := &CalendarHeatMap{Padding: vg.Points(4)}
+	for i, yvs := range years {
+		year := first + i
+		cal := &plotter.Calendar{
+			Values:     yvs,
+			Start:      time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+			End:        time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC),
+			ColorMap:   cm,
+			Empty:      all.Empty,
+			Gap:        all.Gap,
+			MonthStyle: all.MonthStyle,
+		}
+		p, err := plot.New()
+		if err != nil {
+			return nil, err
+		}
+		p.Title.Text = strconv.Itoa(year)
+		p.Add(cal)
+		p.X.Padding, p.Y.Padding = 0, 0
+		p.X.Width, p.Y.Width = 0, 0
+		p.X.Tick.Length, p.Y.Tick.Length = 0, 0
+		p.X.Tick.Marker = cal.MonthTicks()
+		p.Y.Tick.Marker = cal.WeekdayTicks()
+
+		h.Plots = append(h.Plots, p)
+		h.Calendars = append(h.Calendars, cal)
+	}
+	plot.ShareX(h.Plots...)
+	return h, nil
+}
+
+// Draw draws the panels to the canvas with their
+// data areas aligned.
+func (h *CalendarHeatMap) Draw(c draw.Canvas) {
+	plots := make([][]*plot.Plot, len(h.Plots))
+	for i, p := range h.Plots {
+		plots[i] = []*plot.Plot{p}
+	}
+	tiles := draw.Tiles{Rows: len(plots), Cols: 1, PadY: h.Padding}
+	cs := plot.Align(plots, tiles, c)
+	for i, p := range h.Plots {
+		p.Draw(cs[i][0])
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gshk/plot/palette/moreland"
+	"github.com/gshk/plot/plotter"
+	"github.com/gshk/plot/plotutil"
+	"github.com/gshk/plot/vg/draw"
+	"github.com/gshk/plot/vg/vgimg"
+)
+
+func TestCalendarHeatMap(t *testing.T) {
+	start := time.Date(2018, time.October, 1, 0, 0, 0, 0, time.UTC)
+	var vs []plotter.DatedValue
+	for d := 0; d < 500; d++ {
+		vs = append(vs, plotter.DatedValue{Date: start.AddDate(0, 0, d), Value: float64(d % 10)})
+	}
+	cm := moreland.SmoothBlueRed()
+	h, err := plotutil.NewCalendarHeatMap(vs, cm)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(h.Plots) != 3 || len(h.Calendars) != 3 {
+		t.Fatalf("unexpected number of panels: got:%d want:3", len(h.Plots))
+	}
+	for i, want := range []string{"2018", "2019", "2020"} {
+		if got := h.Plots[i].Title.Text; got != want {
+			t.Errorf("unexpected title of panel %d: got:%q want:%q", i, got, want)
+		}
+		if h.Plots[i].X.Max != h.Plots[0].X.Max {
+			t.Errorf("X range of panel %d not shared: got:%v want:%v", i, h.Plots[i].X.Max, h.Plots[0].X.Max)
+		}
+		if h.Calendars[i].ColorMap != cm {
+			t.Errorf("color map of panel %d not shared", i)
+		}
+	}
+	if n := len(h.Calendars[1].Values); n != 365 {
+		t.Errorf("unexpected number of values in 2019: got:%d want:365", n)
+	}
+	if cm.Min() != 0 || cm.Max() != 9 {
+		t.Errorf("unexpected color map range: got:[%v, %v] want:[0, 9]", cm.Min(), cm.Max())
+	}
+	h.Draw(draw.New(vgimg.New(600, 400)))
+}
@@ -0,0 +1,197 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"fmt"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/palette"
+	"github.com/gshk/plot/plotter"
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/draw"
+)
+
+// ClusteredHeatMap is a heat map whose rows and columns are reordered
+// to match hierarchical clusterings of the rows and columns, drawn with
+// the dendrograms of the clusterings in aligned margins to the left of
+// and above the heat map.
+type ClusteredHeatMap struct {
+	// Plot is the plot holding the heat map. The X and
+	// Y axes are labeled with the labels of the column
+	// and row dendrograms, if they have labels.
+	Plot *plot.Plot
+
+	// HeatMap is the heat map of the reordered grid.
+	// Column c and row r of the reordered grid are
+	// located at X=c and Y=r.
+	HeatMap *plotter.HeatMap
+
+	// Rows and Columns are the plots holding the
+	// row and column dendrograms. They are nil
+	// when the respective dendrogram is not given.
+	Rows, Columns *plot.Plot
+
+	// Margin is the fraction of the width and height
+	// of the canvas used for the dendrogram margins.
+	Margin float64
+}
+
+// NewClusteredHeatMap returns a ClusteredHeatMap of g using the palette
+// p, with the columns of g reordered to match the leaf order of cols and
+// the rows reordered to match the leaf order of rows. Either dendrogram
+// may be nil, in which case the corresponding order is unchanged. The
+// dendrograms are copied and their orientation is set to place their
+// roots away from the heat map.
+func NewClusteredHeatMap(g plotter.GridXYZ, p palette.Palette, rows, cols *plotter.Dendrogram) (*ClusteredHeatMap, error) {
+	c, r := g.Dims()
+	grid := reorderedGrid{
+		GridXYZ: g,
+		cols:    identityOrder(c),
+		rows:    identityOrder(r),
+	}
+	if cols != nil {
+		grid.cols = cols.LeafOrder()
+		if len(grid.cols) != c {
+			return nil, fmt.Errorf("plotutil: column dendrogram leaves (%d) != grid columns (%d)", len(grid.cols), c)
+		}
+	}
+	if rows != nil {
+		grid.rows = rows.LeafOrder()
+		if len(grid.rows) != r {
+			return nil, fmt.Errorf("plotutil: row dendrogram leaves (%d) != grid rows (%d)", len(grid.rows), r)
+		}
+	}
+
+	plt, err := plot.New()
+	if err != nil {
+		return nil, err
+	}
+	h := plotter.NewHeatMap(grid, p)
+	plt.Add(h)
+	plt.X.Padding, plt.Y.Padding = 0, 0
+	plt.X.Width, plt.Y.Width = 0, 0
+	plt.X.Tick.Length, plt.Y.Tick.Length = 0, 0
+	plt.X.Tick.Marker = leafTicks(cols, grid.cols)
+	plt.Y.Tick.Marker = leafTicks(rows, grid.rows)
+
+	chm := &ClusteredHeatMap{
+		Plot:    plt,
+		HeatMap: h,
+		Margin:  0.15,
+	}
+
+	if cols != nil {
+		d := *cols
+		d.Orientation = plotter.DendrogramTop
+		d.Labels = nil
+		chm.Columns, err = dendrogramPlot(&d)
+		if err != nil {
+			return nil, err
+		}
+		chm.Columns.X.Min, chm.Columns.X.Max = plt.X.Min, plt.X.Max
+	}
+	if rows != nil {
+		d := *rows
+		d.Orientation = plotter.DendrogramLeft
+		d.Labels = nil
+		chm.Rows, err = dendrogramPlot(&d)
+		if err != nil {
+			return nil, err
+		}
+		chm.Rows.Y.Min, chm.Rows.Y.Max = plt.Y.Min, plt.Y.Max
+	}
+
+	return chm, nil
+}
+
+// dendrogramPlot returns a plot holding d with hidden axes.
+func dendrogramPlot(d *plotter.Dendrogram) (*plot.Plot, error) {
+	p, err := plot.New()
+	if err != nil {
+		return nil, err
+	}
+	p.Add(d)
+	p.HideAxes()
+	p.X.Padding, p.Y.Padding = 0, 0
+	return p, nil
+}
+
+// leafTicks returns a ticker labeling the grid locations with the
+// labels of the dendrogram in the given order. If the dendrogram is
+// nil or has no labels, the returned ticker produces no ticks.
+func leafTicks(d *plotter.Dendrogram, order []int) plot.Ticker {
+	if d == nil || d.Labels == nil {
+		return plot.ConstantTicks{}
+	}
+	ticks := make([]plot.Tick, len(order))
+	for i, id := range order {
+		ticks[i] = plot.Tick{Value: float64(i), Label: d.Labels[id]}
+	}
+	return plot.ConstantTicks(ticks)
+}
+
+// Draw draws the heat map and the dendrograms to the canvas.
+func (h *ClusteredHeatMap) Draw(c draw.Canvas) {
+	size := c.Size()
+	var left, top vg.Length
+	if h.Rows != nil {
+		left = vg.Length(h.Margin) * size.X
+	}
+	if h.Columns != nil {
+		top = vg.Length(h.Margin) * size.Y
+	}
+
+	center := draw.Crop(c, left, 0, 0, -top)
+	h.Plot.Draw(center)
+	dataC := h.Plot.DataCanvas(center)
+
+	if h.Columns != nil {
+		mc := draw.Canvas{
+			Canvas: c.Canvas,
+			Rectangle: vg.Rectangle{
+				Min: vg.Point{X: dataC.Min.X, Y: center.Max.Y},
+				Max: vg.Point{X: dataC.Max.X, Y: c.Max.Y},
+			},
+		}
+		h.Columns.Draw(plot.AlignX(h.Columns, mc, dataC))
+	}
+	if h.Rows != nil {
+		mc := draw.Canvas{
+			Canvas: c.Canvas,
+			Rectangle: vg.Rectangle{
+				Min: vg.Point{X: c.Min.X, Y: dataC.Min.Y},
+				Max: vg.Point{X: center.Min.X, Y: dataC.Max.Y},
+			},
+		}
+		h.Rows.Draw(plot.AlignY(h.Rows, mc, dataC))
+	}
+}
+
+// reorderedGrid is a GridXYZ with reordered columns and rows
+// placed at unit intervals from the origin.
+type reorderedGrid struct {
+	plotter.GridXYZ
+	cols, rows []int
+}
+
+func (g reorderedGrid) Z(c, r int) float64 { return g.GridXYZ.Z(g.cols[c], g.rows[r]) }
+func (g reorderedGrid) X(c int) float64 {
+	_ = g.cols[c]
+	return float64(c)
+}
+func (g reorderedGrid) Y(r int) float64 {
+	_ = g.rows[r]
+	return float64(r)
+}
+
+// identityOrder returns the order 0, 1, ..., n-1.
+func identityOrder(n int) []int {
+	o := make([]int, n)
+	for i := range o {
+		o[i] = i
+	}
+	return o
+}
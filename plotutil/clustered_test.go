@@ -0,0 +1,68 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil_test
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+
+	"github.com/gshk/plot/palette"
+	"github.com/gshk/plot/plotter"
+	"github.com/gshk/plot/plotutil"
+	"github.com/gshk/plot/vg/draw"
+	"github.com/gshk/plot/vg/vgimg"
+)
+
+type unitGrid struct{ mat.Matrix }
+
+func (g unitGrid) Dims() (c, r int)   { r, c = g.Matrix.Dims(); return c, r }
+func (g unitGrid) Z(c, r int) float64 { return g.Matrix.At(r, c) }
+func (g unitGrid) X(c int) float64    { return float64(c) }
+func (g unitGrid) Y(r int) float64    { return float64(r) }
+
+func TestClusteredHeatMap(t *testing.T) {
+	cols, err := plotter.NewDendrogram([]plotter.Merge{
+		{A: 1, B: 2, Height: 1},
+		{A: 3, B: 0, Height: 2},
+	}, []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rows, err := plotter.NewDendrogram([]plotter.Merge{
+		{A: 1, B: 0, Height: 1},
+	}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	g := unitGrid{mat.NewDense(2, 3, []float64{
+		1, 2, 3,
+		4, 5, 6,
+	})}
+	h, err := plotutil.NewClusteredHeatMap(g, palette.Heat(6, 1), rows, cols)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := [][]float64{
+		{5, 6, 4},
+		{2, 3, 1},
+	}
+	for r, row := range want {
+		for c, v := range row {
+			if got := h.HeatMap.GridXYZ.Z(c, r); got != v {
+				t.Errorf("unexpected value at (%d, %d): got:%v want:%v", c, r, got, v)
+			}
+		}
+	}
+
+	// Check that drawing succeeds.
+	h.Draw(draw.New(vgimg.New(200, 200)))
+
+	if _, err := plotutil.NewClusteredHeatMap(g, palette.Heat(6, 1), cols, rows); err == nil {
+		t.Error("expected error for mismatched dendrograms")
+	}
+}
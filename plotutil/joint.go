@@ -0,0 +1,224 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"errors"
+	"math"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/plotter"
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/draw"
+)
+
+// Marginal specifies how the marginal distributions
+// of a JointPlot are drawn.
+type Marginal int
+
+const (
+	// MarginalHistogram draws the marginal
+	// distributions as histograms.
+	MarginalHistogram Marginal = iota
+
+	// MarginalDensity draws the marginal distributions
+	// as Gaussian kernel density estimates.
+	MarginalDensity
+)
+
+// densityPoints is the number of points at which
+// marginal density estimates are evaluated.
+const densityPoints = 100
+
+// JointPlot is a plot of the joint distribution of two variables drawn
+// with the marginal distributions of the variables in margins above and
+// to the right of it. The X axis of the top margin and the Y axis of the
+// right margin share the ranges and scales of the central plot, and the
+// data areas of the margins are aligned with the data area of the
+// central plot.
+type JointPlot struct {
+	// Plot is the central plot holding the plotter
+	// of the joint distribution.
+	Plot *plot.Plot
+
+	// Top and Right are the plots holding the marginal
+	// distributions of the X and Y values. Their axes
+	// are hidden. Either may be set to nil to omit
+	// the margin.
+	Top, Right *plot.Plot
+
+	// Margin is the fraction of the width and height
+	// of the canvas used for the margins.
+	Margin float64
+}
+
+// NewJointPlot returns a JointPlot with the plotter p in the central plot
+// and the marginal distributions of the X and Y values of xys drawn in the
+// margins using the given kind of Marginal. The number of bins used by
+// marginal histograms is given by bins, which is ignored for marginal
+// densities. The plotter p is typically a Scatter of xys, or a plotter
+// that bins xys in two dimensions.
+func NewJointPlot(p plot.Plotter, xys plotter.XYer, m Marginal, bins int) (*JointPlot, error) {
+	if xys.Len() == 0 {
+		return nil, plotter.ErrNoData
+	}
+
+	plt, err := plot.New()
+	if err != nil {
+		return nil, err
+	}
+	plt.Add(p)
+
+	top, err := marginalPlot(plotter.XValues{XYer: xys}, m, bins, false)
+	if err != nil {
+		return nil, err
+	}
+	right, err := marginalPlot(plotter.YValues{XYer: xys}, m, bins, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JointPlot{
+		Plot:   plt,
+		Top:    top,
+		Right:  right,
+		Margin: 0.2,
+	}, nil
+}
+
+// marginalPlot returns a plot with hidden axes holding the marginal
+// distribution of vs. If horizontal is true, the values of vs are placed
+// along the Y axis.
+func marginalPlot(vs plotter.Valuer, m Marginal, bins int, horizontal bool) (*plot.Plot, error) {
+	p, err := plot.New()
+	if err != nil {
+		return nil, err
+	}
+	p.HideAxes()
+	p.X.Padding, p.Y.Padding = 0, 0
+
+	switch m {
+	case MarginalHistogram:
+		h, err := plotter.NewHist(vs, bins)
+		if err != nil {
+			return nil, err
+		}
+		h.Horizontal = horizontal
+		p.Add(h)
+	case MarginalDensity:
+		xys := gaussianKDE(vs, densityPoints)
+		if horizontal {
+			for i := range xys {
+				xys[i].X, xys[i].Y = xys[i].Y, xys[i].X
+			}
+		}
+		l, err := plotter.NewLine(xys)
+		if err != nil {
+			return nil, err
+		}
+		p.Add(l)
+		if horizontal {
+			p.X.Min = 0
+		} else {
+			p.Y.Min = 0
+		}
+	default:
+		return nil, errors.New("plotutil: unknown marginal kind")
+	}
+	return p, nil
+}
+
+// gaussianKDE returns a Gaussian kernel density estimate of the
+// distribution of vs evaluated at n evenly spaced points spanning the
+// range of vs. The bandwidth is chosen using Silverman's rule of thumb.
+func gaussianKDE(vs plotter.Valuer, n int) plotter.XYs {
+	min, max := plotter.Range(vs)
+	l := vs.Len()
+
+	var mean float64
+	for i := 0; i < l; i++ {
+		mean += vs.Value(i)
+	}
+	mean /= float64(l)
+	var ss float64
+	for i := 0; i < l; i++ {
+		d := vs.Value(i) - mean
+		ss += d * d
+	}
+	bw := 1.06 * math.Sqrt(ss/float64(l)) * math.Pow(float64(l), -0.2)
+	if bw == 0 {
+		// All values are equal, so use a
+		// bandwidth that gives a visible peak.
+		bw = math.Max(math.Abs(mean), 1) * 1e-3
+	}
+
+	xys := make(plotter.XYs, n)
+	for k := range xys {
+		x := min
+		if n > 1 {
+			x += (max - min) * float64(k) / float64(n-1)
+		}
+		var d float64
+		for i := 0; i < l; i++ {
+			z := (x - vs.Value(i)) / bw
+			d += math.Exp(-z * z / 2)
+		}
+		xys[k].X = x
+		xys[k].Y = d / (float64(l) * bw * math.Sqrt(2*math.Pi))
+	}
+	return xys
+}
+
+// Draw draws the central plot and the margins to the canvas.
+// The axes are shared between copies of the plots, so the
+// plots of the JointPlot are left unchanged.
+func (j *JointPlot) Draw(c draw.Canvas) {
+	plt, top, right := copyPlot(j.Plot), copyPlot(j.Top), copyPlot(j.Right)
+	plot.ShareX(plt, top)
+	plot.ShareY(plt, right)
+
+	size := c.Size()
+	var rightW, topH vg.Length
+	if right != nil {
+		rightW = vg.Length(j.Margin) * size.X
+	}
+	if top != nil {
+		topH = vg.Length(j.Margin) * size.Y
+	}
+
+	center := draw.Crop(c, 0, -rightW, 0, -topH)
+	plt.Draw(center)
+	dataC := plt.DataCanvas(center)
+
+	if top != nil {
+		mc := draw.Canvas{
+			Canvas: c.Canvas,
+			Rectangle: vg.Rectangle{
+				Min: vg.Point{X: dataC.Min.X, Y: center.Max.Y},
+				Max: vg.Point{X: dataC.Max.X, Y: c.Max.Y},
+			},
+		}
+		top.Draw(plot.AlignX(top, mc, dataC))
+	}
+	if right != nil {
+		mc := draw.Canvas{
+			Canvas: c.Canvas,
+			Rectangle: vg.Rectangle{
+				Min: vg.Point{X: center.Max.X, Y: dataC.Min.Y},
+				Max: vg.Point{X: c.Max.X, Y: dataC.Max.Y},
+			},
+		}
+		right.Draw(plot.AlignY(right, mc, dataC))
+	}
+}
+
+// copyPlot returns a shallow copy of p, or nil if p is nil.
+func copyPlot(p *plot.Plot) *plot.Plot {
+	if p == nil {
+		return nil
+	}
+	cp := *p
+	return &cp
+}
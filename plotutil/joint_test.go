@@ -0,0 +1,55 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil_test
+
+import (
+	"testing"
+
+	"golang.org/x/exp/rand"
+
+	"github.com/gshk/plot/plotter"
+	"github.com/gshk/plot/plotutil"
+	"github.com/gshk/plot/vg/draw"
+	"github.com/gshk/plot/vg/vgimg"
+)
+
+func TestJointPlot(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	xys := make(plotter.XYs, 100)
+	for i := range xys {
+		xys[i].X = rnd.NormFloat64()
+		xys[i].Y = xys[i].X + rnd.NormFloat64()
+	}
+	s, err := plotter.NewScatter(xys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, m := range []plotutil.Marginal{plotutil.MarginalHistogram, plotutil.MarginalDensity} {
+		j, err := plotutil.NewJointPlot(s, xys, m, 10)
+		if err != nil {
+			t.Fatalf("unexpected error for marginal %d: %v", m, err)
+		}
+		j.Plot.X.Max, j.Plot.Y.Min = 10, -10
+		top, right := j.Top.X, j.Right.Y
+		j.Draw(draw.New(vgimg.New(300, 300)))
+
+		if j.Top.X.Min != top.Min || j.Top.X.Max != top.Max {
+			t.Errorf("top margin X range changed by drawing for marginal %d: got:[%v, %v] want:[%v, %v]",
+				m, j.Top.X.Min, j.Top.X.Max, top.Min, top.Max)
+		}
+		if j.Right.Y.Min != right.Min || j.Right.Y.Max != right.Max {
+			t.Errorf("right margin Y range changed by drawing for marginal %d: got:[%v, %v] want:[%v, %v]",
+				m, j.Right.Y.Min, j.Right.Y.Max, right.Min, right.Max)
+		}
+	}
+
+	if _, err := plotutil.NewJointPlot(s, xys, plotutil.MarginalHistogram, 0); err == nil {
+		t.Error("expected error for non-positive number of bins")
+	}
+	if _, err := plotutil.NewJointPlot(s, plotter.XYs{}, plotutil.MarginalDensity, 10); err == nil {
+		t.Error("expected error for empty data")
+	}
+}
@@ -0,0 +1,352 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/plotter"
+	"github.com/gshk/plot/vg"
+)
+
+// smoothSteps is the number of line segments drawn between
+// neighboring points of smoothed stacked areas.
+const smoothSteps = 16
+
+// StackOffset specifies the baseline of stacked areas.
+type StackOffset int
+
+const (
+	// ZeroOffset stacks the layers upward from zero.
+	ZeroOffset StackOffset = iota
+
+	// SilhouetteOffset centers the stack on zero, so
+	// that the areas are symmetric about the X axis.
+	SilhouetteOffset
+
+	// WiggleOffset moves the baseline to minimize the
+	// weighted change in slope of the layers, giving
+	// a streamgraph.
+	WiggleOffset
+
+	// PercentOffset stacks the layers upward from zero
+	// after scaling each column of values so that the
+	// layers sum to 100.
+	PercentOffset
+)
+
+// StackOrder specifies the order in which layers
+// are stacked.
+type StackOrder int
+
+const (
+	// InputOrder stacks the layers in the order they
+	// are given, with the first at the bottom.
+	InputOrder StackOrder = iota
+
+	// InsideOutOrder places the layers that peak
+	// earliest in the middle of the stack and those that
+	// peak later alternately above and below them,
+	// balancing the totals on either side. It is
+	// suited to streamgraphs.
+	InsideOutOrder
+)
+
+// StackOptions specifies how AddStackedAreas
+// stacks its layers.
+type StackOptions struct {
+	// Offset is the baseline of the stack.
+	Offset StackOffset
+
+	// Order is the order of the layers
+	// in the stack.
+	Order StackOrder
+
+	// Smooth specifies that the layer thicknesses
+	// are interpolated by monotone cubic curves
+	// rather than straight lines before they are
+	// stacked. The interpolation does not overshoot
+	// the values, so the layers never cross.
+	Smooth bool
+}
+
+// AddStackedAreas adds filled areas for layers of values stacked
+// on top of each other to a plot. Unlike AddStackedAreaPlots, the
+// values are the thicknesses of the layers rather than their sums.
+// The variadic arguments must be either strings or plotter.Valuers.
+// Each valuer adds a layer with the next color from the Color
+// function. If a plotter.Valuer is immediately preceeded by a
+// string then the string value is used to label the legend. Legend
+// entries are added from the top of the stack down.
+//
+// The X values must be strictly increasing and the layer values
+// must be finite and non-negative. If an error occurs then none of
+// the plotters are added to the plot, and the error is returned.
+func AddStackedAreas(plt *plot.Plot, xs plotter.Valuer, opts StackOptions, vs ...interface{}) error {
+	x, err := plotter.CopyValues(xs)
+	if err != nil {
+		return err
+	}
+	for i := 1; i < len(x); i++ {
+		if !(x[i] > x[i-1]) {
+			return errors.New("plotutil: stacked area X values not strictly increasing")
+		}
+	}
+
+	var layers []plotter.Values
+	var names []string
+	name := ""
+	for _, v := range vs {
+		switch t := v.(type) {
+		case string:
+			name = t
+
+		case plotter.Valuer:
+			if xs.Len() != t.Len() {
+				return errors.New("X/Y length mismatch")
+			}
+			l, err := plotter.CopyValues(t)
+			if err != nil {
+				return err
+			}
+			if err := plotter.CheckFinite(l...); err != nil {
+				return err
+			}
+			for _, y := range l {
+				if y < 0 {
+					return errors.New("plotutil: negative stacked area value")
+				}
+			}
+			layers = append(layers, l)
+			names = append(names, name)
+			name = ""
+
+		default:
+			panic(fmt.Sprintf("plotutil: AddStackedAreas handles strings and plotter.Valuers, got %T", t))
+		}
+	}
+	if len(layers) == 0 {
+		return nil
+	}
+
+	if opts.Smooth && len(x) > 2 {
+		sx := smoothX(x)
+		for i, l := range layers {
+			layers[i] = monotoneCubic(x, l, sx)
+		}
+		x = sx
+	}
+	if opts.Offset == PercentOffset {
+		layers = percentLayers(layers)
+	}
+	var order []int
+	switch opts.Order {
+	case InputOrder:
+		order = make([]int, len(layers))
+		for i := range order {
+			order[i] = i
+		}
+	case InsideOutOrder:
+		order = insideOutOrder(layers)
+	default:
+		panic("plotutil: unknown stack order")
+	}
+	stacked := make([]plotter.Values, len(layers))
+	for i, j := range order {
+		stacked[i] = layers[j]
+	}
+	base := stackBaseline(stacked, opts.Offset)
+
+	polys := make([]*plotter.Polygon, len(stacked))
+	low := base
+	for i, l := range stacked {
+		high := make(plotter.Values, len(low))
+		ring := make(plotter.XYs, 0, 2*len(x))
+		for j := range x {
+			high[j] = low[j] + l[j]
+			ring = append(ring, plotter.XY{X: x[j], Y: high[j]})
+		}
+		for j := len(x) - 1; j >= 0; j-- {
+			ring = append(ring, plotter.XY{X: x[j], Y: low[j]})
+		}
+		p, err := plotter.NewPolygon(ring)
+		if err != nil {
+			return err
+		}
+		p.LineStyle.Width = vg.Points(0)
+		p.Color = Color(order[i])
+		polys[i] = p
+		low = high
+	}
+
+	for _, p := range polys {
+		plt.Add(p)
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		if name := names[order[i]]; name != "" {
+			plt.Legend.Add(name, polys[i])
+		}
+	}
+	return nil
+}
+
+// percentLayers returns the layers scaled so that each
+// column sums to 100. Columns that sum to zero are left
+// unchanged.
+func percentLayers(layers []plotter.Values) []plotter.Values {
+	scaled := make([]plotter.Values, len(layers))
+	for i, l := range layers {
+		scaled[i] = append(plotter.Values(nil), l...)
+	}
+	for j := range layers[0] {
+		var sum float64
+		for _, l := range layers {
+			sum += l[j]
+		}
+		if sum == 0 {
+			continue
+		}
+		for _, l := range scaled {
+			l[j] *= 100 / sum
+		}
+	}
+	return scaled
+}
+
+// insideOutOrder returns the indices of the layers in the inside-out
+// stacking order, from the bottom of the stack to the top.
+func insideOutOrder(layers []plotter.Values) []int {
+	peaks := make([]int, len(layers))
+	sums := make([]float64, len(layers))
+	for i, l := range layers {
+		for j, v := range l {
+			if v > l[peaks[i]] {
+				peaks[i] = j
+			}
+			sums[i] += v
+		}
+	}
+	byPeak := make([]int, len(layers))
+	for i := range byPeak {
+		byPeak[i] = i
+	}
+	sort.SliceStable(byPeak, func(a, b int) bool { return peaks[byPeak[a]] < peaks[byPeak[b]] })
+
+	var tops, bottoms []int
+	var top, bottom float64
+	for _, i := range byPeak {
+		if top < bottom {
+			top += sums[i]
+			tops = append(tops, i)
+		} else {
+			bottom += sums[i]
+			bottoms = append(bottoms, i)
+		}
+	}
+	order := make([]int, 0, len(layers))
+	for i := len(bottoms) - 1; i >= 0; i-- {
+		order = append(order, bottoms[i])
+	}
+	return append(order, tops...)
+}
+
+// stackBaseline returns the lower boundary of the bottom
+// layer of the stacked layers for the offset.
+func stackBaseline(layers []plotter.Values, offset StackOffset) plotter.Values {
+	n := len(layers[0])
+	base := make(plotter.Values, n)
+	switch offset {
+	case ZeroOffset, PercentOffset:
+	case SilhouetteOffset:
+		for j := range base {
+			for _, l := range layers {
+				base[j] -= l[j] / 2
+			}
+		}
+	case WiggleOffset:
+		// The baseline minimizes the sum of the squared
+		// slopes of the layer midlines weighted by the
+		// layer thicknesses, after Byron and Wattenberg,
+		// "Stacked Graphs – Geometry & Aesthetics", 2008.
+		for j := 1; j < n; j++ {
+			var total, weighted, below float64
+			for _, l := range layers {
+				d := l[j] - l[j-1]
+				weighted += (below + d/2) * l[j]
+				below += d
+				total += l[j]
+			}
+			base[j] = base[j-1]
+			if total != 0 {
+				base[j] -= weighted / total
+			}
+		}
+	default:
+		panic("plotutil: unknown stack offset")
+	}
+	return base
+}
+
+// smoothX returns the X values of a smoothed curve through
+// points at x, with smoothSteps segments between each pair of
+// neighboring points.
+func smoothX(x plotter.Values) plotter.Values {
+	sx := make(plotter.Values, 0, (len(x)-1)*smoothSteps+1)
+	for i := 0; i < len(x)-1; i++ {
+		for k := 0; k < smoothSteps; k++ {
+			sx = append(sx, x[i]+(x[i+1]-x[i])*float64(k)/smoothSteps)
+		}
+	}
+	return append(sx, x[len(x)-1])
+}
+
+// monotoneCubic returns the values at the X values sx of the
+// monotone cubic Hermite interpolant of the points at x and y, using
+// the tangents of Fritsch and Carlson. The interpolant lies between
+// the values at the ends of each interval, so it does not overshoot.
+// The values of sx must be within the range of x.
+func monotoneCubic(x, y, sx plotter.Values) plotter.Values {
+	n := len(x)
+	slopes := make([]float64, n-1)
+	for i := range slopes {
+		slopes[i] = (y[i+1] - y[i]) / (x[i+1] - x[i])
+	}
+	m := make([]float64, n)
+	m[0], m[n-1] = slopes[0], slopes[n-2]
+	for i := 1; i < n-1; i++ {
+		if slopes[i-1]*slopes[i] > 0 {
+			m[i] = (slopes[i-1] + slopes[i]) / 2
+		}
+	}
+	for i, s := range slopes {
+		if s == 0 {
+			m[i], m[i+1] = 0, 0
+			continue
+		}
+		a, b := m[i]/s, m[i+1]/s
+		if h := math.Hypot(a, b); h > 3 {
+			m[i] = 3 * a / h * s
+			m[i+1] = 3 * b / h * s
+		}
+	}
+
+	sy := make(plotter.Values, len(sx))
+	i := 0
+	for k, v := range sx {
+		for i < n-2 && v > x[i+1] {
+			i++
+		}
+		h := x[i+1] - x[i]
+		t := (v - x[i]) / h
+		t2, t3 := t*t, t*t*t
+		sy[k] = (2*t3-3*t2+1)*y[i] + (t3-2*t2+t)*h*m[i] +
+			(-2*t3+3*t2)*y[i+1] + (t3-t2)*h*m[i+1]
+	}
+	return sy
+}
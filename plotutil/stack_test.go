@@ -0,0 +1,136 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotutil_test
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"github.com/gshk/plot"
+	"github.com/gshk/plot/cmpimg"
+	"github.com/gshk/plot/plotter"
+	"github.com/gshk/plot/plotutil"
+	"github.com/gshk/plot/vg/draw"
+	"github.com/gshk/plot/vg/recorder"
+)
+
+// ExampleAddStackedAreas draws a smoothed streamgraph of the
+// popularity of five topics over a year.
+func ExampleAddStackedAreas() {
+	months := plotter.Values{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+	topics := []string{"Go", "Rust", "Zig", "Nim", "Odin"}
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Topics"
+	p.X.Label.Text = "Month"
+	p.HideY()
+
+	var vs []interface{}
+	for i, name := range topics {
+		layer := make(plotter.Values, len(months))
+		for j, m := range months {
+			d := m - float64(2*i+2)
+			layer[j] = 10 * math.Exp(-d*d/8)
+		}
+		vs = append(vs, name, layer)
+	}
+	opts := plotutil.StackOptions{
+		Offset: plotutil.WiggleOffset,
+		Order:  plotutil.InsideOutOrder,
+		Smooth: true,
+	}
+	err = plotutil.AddStackedAreas(p, months, opts, vs...)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	err = p.Save(300, 200, "testdata/streamgraph.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestAddStackedAreasExample(t *testing.T) {
+	cmpimg.CheckPlot(ExampleAddStackedAreas, t, "streamgraph.png")
+}
+
+func TestAddStackedAreas(t *testing.T) {
+	xs := plotter.Values{0, 1, 2, 3}
+	a := plotter.Values{1, 4, 1, 0}
+	b := plotter.Values{0, 1, 5, 2}
+	c := plotter.Values{3, 1, 0, 6}
+
+	for _, test := range []struct {
+		opts       plotutil.StackOptions
+		ymin, ymax float64
+	}{
+		{opts: plotutil.StackOptions{Offset: plotutil.ZeroOffset}, ymin: 0, ymax: 8},
+		{opts: plotutil.StackOptions{Offset: plotutil.SilhouetteOffset}, ymin: -4, ymax: 4},
+		{opts: plotutil.StackOptions{Offset: plotutil.PercentOffset}, ymin: 0, ymax: 100},
+		{opts: plotutil.StackOptions{Offset: plotutil.PercentOffset, Smooth: true}, ymin: 0, ymax: 100},
+	} {
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		err = plotutil.AddStackedAreas(p, xs, test.opts, a, b, c)
+		if err != nil {
+			t.Fatalf("unexpected error for %+v: %v", test.opts, err)
+		}
+		if math.Abs(p.Y.Min-test.ymin) > 1e-9 || math.Abs(p.Y.Max-test.ymax) > 1e-9 {
+			t.Errorf("unexpected Y range for %+v: got:[%v, %v] want:[%v, %v]",
+				test.opts, p.Y.Min, p.Y.Max, test.ymin, test.ymax)
+		}
+	}
+
+	// The legend lists the layers from the top of
+	// the stack down.
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	opts := plotutil.StackOptions{Order: plotutil.InsideOutOrder}
+	err = plotutil.AddStackedAreas(p, xs, opts, "a", a, "b", b, "c", c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.HideAxes()
+	var rec recorder.Canvas
+	p.Draw(draw.NewCanvas(&rec, 200, 200))
+	var got []string
+	for _, act := range rec.Actions {
+		if s, ok := act.(*recorder.FillString); ok {
+			got = append(got, s.String)
+		}
+	}
+	// Peaks are at a:1, b:2 and c:3, so a is placed
+	// first, b above it and c below it.
+	want := []string{"b", "a", "c"}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected legend entries: got:%q want:%q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("unexpected legend order: got:%q want:%q", got, want)
+			break
+		}
+	}
+
+	for _, vs := range [][]interface{}{
+		{plotter.Values{1, 2}},
+		{plotter.Values{1, -1, 0, 0}},
+		{plotter.Values{1, math.NaN(), 0, 0}},
+	} {
+		if err := plotutil.AddStackedAreas(p, xs, plotutil.StackOptions{}, vs...); err == nil {
+			t.Errorf("expected error for values %v", vs)
+		}
+	}
+	if err := plotutil.AddStackedAreas(p, plotter.Values{0, 2, 1, 3}, plotutil.StackOptions{}, a); err == nil {
+		t.Error("expected error for unordered X values")
+	}
+}
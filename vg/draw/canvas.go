@@ -40,6 +40,32 @@ type TextStyle struct {
 	// XAlign and YAlign specify the alignment of the text.
 	XAlign XAlignment
 	YAlign YAlignment
+
+	// Markup specifies whether the text is marked up with a
+	// subset of the TeX notation. In marked up text ^ and _
+	// raise the following character or group in braces as a
+	// superscript or lower it as a subscript, as in "m/s^2"
+	// or "H_{2}O". \textbf{...} and \textit{...} draw groups
+	// with the bold or italic variant of the font, and braces
+	// group text without changing it. The characters \, {, },
+	// ^ and _ are written literally by preceding them with a
+	// backslash. Parts of the text in fonts other than Font
+	// are drawn on canvases implementing vg.FontFiller, such
+	// as vgtex canvases, with FillFont.
+	//
+	// Text between dollar signs is a formula laid out as TeX
+	// math. Formulas may hold Greek letters such as \alpha and
+	// \Omega, symbols such as \infty, \pm, \leq and \to, the
+	// big operators \sum, \prod and \int, functions such as
+	// \sin and \log, \frac{num}{den}, \sqrt{x} and \sqrt[n]{x},
+	// the accents \hat, \bar, \vec, \dot, \ddot and \tilde,
+	// \overline and \underline, \left and \right delimiters,
+	// the fonts \mathrm, \mathbf, \mathit and \text, and the
+	// spaces \, \; \quad and \qquad. Formulas are drawn with
+	// the fonts of the text and outlines of their glyphs, or
+	// passed to canvases implementing vg.MathFiller. A dollar
+	// sign is written literally as \$.
+	Markup bool
 }
 
 // XAlignment specifies text alignment in the X direction. Three preset
@@ -80,6 +106,19 @@ type LineStyle struct {
 
 	Dashes   []vg.Length
 	DashOffs vg.Length
+
+	// Cap is the shape of the ends of the line.
+	Cap vg.LineCap
+
+	// Join is the shape of the corners of the line.
+	// The zero value is vg.DefaultJoin. Miter joins
+	// of dashed lines are beveled by vgimg.
+	Join vg.LineJoin
+
+	// MiterLimit is the miter limit of miter joins.
+	// If MiterLimit is zero vg.DefaultMiterLimit
+	// is used.
+	MiterLimit float64
 }
 
 // A GlyphStyle specifies the look of a glyph used to draw
@@ -413,6 +452,13 @@ func (c *Canvas) SetLineStyle(sty LineStyle) {
 		dashDots = append(dashDots, dash)
 	}
 	c.SetLineDash(dashDots, sty.DashOffs)
+	c.SetLineCap(sty.Cap)
+	c.SetLineJoin(sty.Join)
+	limit := sty.MiterLimit
+	if limit == 0 {
+		limit = vg.DefaultMiterLimit
+	}
+	c.SetMiterLimit(limit)
 }
 
 // StrokeLines draws a line connecting a set of points
@@ -531,6 +577,78 @@ func (c *Canvas) FillPolygon(clr color.Color, pts []vg.Point) {
 	c.Fill(p)
 }
 
+// FillHatch fills the path with the hatch pattern h using the
+// even-odd rule. If the underlying vg.Canvas implements
+// vg.HatchFiller the pattern is drawn by the canvas, otherwise
+// it is drawn by vg.DrawHatch.
+func (c *Canvas) FillHatch(h vg.Hatch, p vg.Path) {
+	if h.Pattern == vg.NoHatch {
+		return
+	}
+	if hf, ok := backend(c.Canvas).(vg.HatchFiller); ok {
+		hf.FillHatch(p, h)
+		return
+	}
+	vg.DrawHatch(c.Canvas, p, h)
+}
+
+// backend returns the vg.Canvas underlying any
+// draw.Canvas wrappers around c.
+func backend(c vg.Canvas) vg.Canvas {
+	for {
+		switch w := c.(type) {
+		case Canvas:
+			c = w.Canvas
+		case *Canvas:
+			c = w.Canvas
+		default:
+			return c
+		}
+	}
+}
+
+// FillPolygonHatch fills a polygon with the hatch pattern h.
+func (c *Canvas) FillPolygonHatch(h vg.Hatch, pts []vg.Point) {
+	if len(pts) == 0 || h.Pattern == vg.NoHatch {
+		return
+	}
+	c.FillHatch(h, polygonPath(pts))
+}
+
+// FillGradient fills the path with the gradient g. If the
+// underlying vg.Canvas implements vg.GradientFiller the
+// gradient is drawn natively, otherwise it is drawn by
+// vg.DrawGradient.
+func (c *Canvas) FillGradient(g vg.Gradient, p vg.Path) {
+	if g.Kind == vg.NoGradient {
+		return
+	}
+	if gf, ok := backend(c.Canvas).(vg.GradientFiller); ok {
+		gf.FillGradient(p, g)
+		return
+	}
+	vg.DrawGradient(c.Canvas, p, g)
+}
+
+// FillPolygonGradient fills a polygon with the gradient g.
+func (c *Canvas) FillPolygonGradient(g vg.Gradient, pts []vg.Point) {
+	if len(pts) == 0 || g.Kind == vg.NoGradient {
+		return
+	}
+	c.FillGradient(g, polygonPath(pts))
+}
+
+// polygonPath returns the closed path through pts.
+func polygonPath(pts []vg.Point) vg.Path {
+	var p vg.Path
+	p.Move(pts[0])
+	for _, pt := range pts[1:] {
+		p.Line(pt)
+	}
+	p.Close()
+	return p
+}
+
 // ClipPolygonXY returns a slice of lines that
 // represent the given polygon clipped in both
 // X and Y directions.
@@ -632,11 +750,28 @@ func (c *Canvas) FillText(sty TextStyle, pt vg.Point, txt string) {
 
 	nl := textNLines(txt)
 	ht := sty.Height(txt)
-	pt.Y += ht*vg.Length(sty.YAlign) - sty.Font.Extents().Ascent
+	_, below := sty.overhang(txt)
+	pt.Y += ht*vg.Length(sty.YAlign) - sty.Font.Extents().Ascent + below
+	mf, native := backend(c.Canvas).(vg.MathFiller)
+	ff, fonts := backend(c.Canvas).(vg.FontFiller)
 	for i, line := range strings.Split(txt, "\n") {
-		xoffs := vg.Length(sty.XAlign) * sty.Font.Width(line)
+		runs := sty.runs(line)
+		xoffs := vg.Length(sty.XAlign) * runsWidth(runs)
 		n := vg.Length(nl - i)
-		c.FillString(sty.Font, pt.Add(vg.Point{X: xoffs, Y: n * sty.Font.Size}), line)
+		for _, r := range runs {
+			at := pt.Add(vg.Point{X: xoffs, Y: n*sty.Font.Size + r.shift})
+			switch {
+			case r.math != nil && native:
+				mf.FillMath(r.font, at, r.text)
+			case r.math != nil:
+				r.math.draw(c, at)
+			case fonts && r.font != sty.Font:
+				ff.FillFont(r.font, at, r.text)
+			default:
+				c.FillString(r.font, at, r.text)
+			}
+			xoffs += r.width()
+		}
 	}
 
 	if sty.Rotation != 0 {
@@ -658,12 +793,20 @@ func rotatePoint(theta float64, p vg.Point) vg.Point {
 	}
 }
 
+// runs returns the runs of a line of text drawn with the style.
+func (sty TextStyle) runs(line string) []textRun {
+	if sty.Markup {
+		return markupRuns(sty.Font, line)
+	}
+	return []textRun{{font: sty.Font, text: line}}
+}
+
 // Width returns the width of lines of text
 // when using the given font before any text rotation is applied.
 func (sty TextStyle) Width(txt string) (max vg.Length) {
 	txt = strings.TrimRight(txt, "\n")
 	for _, line := range strings.Split(txt, "\n") {
-		if w := sty.Font.Width(line); w > max {
+		if w := runsWidth(sty.runs(line)); w > max {
 			max = w
 		}
 	}
@@ -672,13 +815,35 @@ func (sty TextStyle) Width(txt string) (max vg.Length) {
 
 // Height returns the height of the text when using
 // the given font before any text rotation is applied.
+// The height of marked up text includes superscripts
+// and formulas that rise above the first line or drop
+// below the last line.
 func (sty TextStyle) Height(txt string) vg.Length {
 	nl := textNLines(txt)
 	if nl == 0 {
 		return vg.Length(0)
 	}
 	e := sty.Font.Extents()
-	return e.Height*vg.Length(nl-1) + e.Ascent
+	above, below := sty.overhang(txt)
+	return e.Height*vg.Length(nl-1) + e.Ascent + above + below
+}
+
+// overhang returns how far marked up text rises above the
+// ascent of the font on its first line and drops below the
+// descent of the font on its last line.
+func (sty TextStyle) overhang(txt string) (above, below vg.Length) {
+	if !sty.Markup || textNLines(txt) == 0 {
+		return 0, 0
+	}
+	e := sty.Font.Extents()
+	lines := strings.Split(strings.TrimRight(txt, "\n"), "\n")
+	for _, r := range sty.runs(lines[0]) {
+		above = max(above, r.shift+r.ascent()-e.Ascent)
+	}
+	for _, r := range sty.runs(lines[len(lines)-1]) {
+		below = max(below, r.descent()-r.shift+e.Descent)
+	}
+	return above, below
 }
 
 // Rectangle returns a rectangle giving the bounds of
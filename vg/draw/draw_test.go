@@ -115,3 +115,87 @@ func TestTile(t *testing.T) {
 		}
 	}
 }
+
+// fillRecorder is a recorder.Canvas that records
+// native hatch and gradient fills.
+type fillRecorder struct {
+	recorder.Canvas
+	hatches   []vg.Hatch
+	gradients []vg.Gradient
+}
+
+func (c *fillRecorder) FillHatch(_ vg.Path, h vg.Hatch) {
+	c.hatches = append(c.hatches, h)
+}
+
+func (c *fillRecorder) FillGradient(_ vg.Path, g vg.Gradient) {
+	c.gradients = append(c.gradients, g)
+}
+
+func TestFillHatch(t *testing.T) {
+	h := vg.Hatch{Pattern: vg.DiagonalHatch, Spacing: 1, Width: 0.1}
+	pts := []vg.Point{{X: 0, Y: 0}, {X: 3, Y: 0}, {X: 3, Y: 3}}
+
+	// Native hatch fills are found through
+	// draw.Canvas wrappers.
+	var native fillRecorder
+	c := NewCanvas(&native, 6, 3)
+	wrapped := Canvas{Canvas: c, Rectangle: c.Rectangle}
+	wrapped.FillPolygonHatch(h, pts)
+	wrapped.FillPolygonHatch(vg.Hatch{}, pts)
+	if !reflect.DeepEqual(native.hatches, []vg.Hatch{h}) {
+		t.Errorf("unexpected native hatch fills: got:%v want:%v", native.hatches, []vg.Hatch{h})
+	}
+	if len(native.Actions) != 0 {
+		t.Errorf("unexpected actions for native hatch fill: %v", native.Actions)
+	}
+
+	// Other canvases are hatched by stroking lines.
+	var r recorder.Canvas
+	c = NewCanvas(&r, 6, 3)
+	c.FillPolygonHatch(h, pts)
+	var strokes int
+	for _, a := range r.Actions {
+		if _, ok := a.(*recorder.Stroke); ok {
+			strokes++
+		}
+	}
+	if strokes != 1 {
+		t.Errorf("unexpected number of strokes for hatch fill: got:%d want:1", strokes)
+	}
+}
+
+func TestFillGradient(t *testing.T) {
+	g := vg.Gradient{
+		Kind:  vg.LinearGradient,
+		End:   vg.Point{X: 3},
+		Stops: []vg.GradientStop{{Offset: 0, Color: color.White}, {Offset: 1, Color: color.Black}},
+	}
+	pts := []vg.Point{{X: 0, Y: 0}, {X: 3, Y: 0}, {X: 3, Y: 3}}
+
+	var native fillRecorder
+	c := NewCanvas(&native, 6, 3)
+	wrapped := Canvas{Canvas: c, Rectangle: c.Rectangle}
+	wrapped.FillPolygonGradient(g, pts)
+	wrapped.FillPolygonGradient(vg.Gradient{}, pts)
+	if !reflect.DeepEqual(native.gradients, []vg.Gradient{g}) {
+		t.Errorf("unexpected native gradient fills: got:%v want:%v", native.gradients, []vg.Gradient{g})
+	}
+	if len(native.Actions) != 0 {
+		t.Errorf("unexpected actions for native gradient fill: %v", native.Actions)
+	}
+
+	// Other canvases are filled with bands of color.
+	var r recorder.Canvas
+	c = NewCanvas(&r, 6, 3)
+	c.FillPolygonGradient(g, pts)
+	var fills int
+	for _, a := range r.Actions {
+		if _, ok := a.(*recorder.Fill); ok {
+			fills++
+		}
+	}
+	if fills < 2 {
+		t.Errorf("unexpected number of fills for gradient: got:%d want:>1", fills)
+	}
+}
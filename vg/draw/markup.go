@@ -0,0 +1,248 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gshk/plot/vg"
+)
+
+// Scripts are drawn at scriptScale times the size of the text
+// they are attached to, with their baselines raised by supShift
+// or lowered by subShift times that size.
+const (
+	scriptScale = 0.7
+	supShift    = 0.4
+	subShift    = 0.2
+)
+
+// fontFamilies lists the regular, bold, italic and
+// bold italic variants of the fonts in vg.FontMap.
+var fontFamilies = [][4]string{
+	{"Courier", "Courier-Bold", "Courier-Oblique", "Courier-BoldOblique"},
+	{"Helvetica", "Helvetica-Bold", "Helvetica-Oblique", "Helvetica-BoldOblique"},
+	{"Times-Roman", "Times-Bold", "Times-Italic", "Times-BoldItalic"},
+}
+
+// fontVariant returns the family of the named font in
+// fontFamilies and whether it is bold and italic. The
+// returned family is nil if the font is not listed.
+func fontVariant(name string) (family *[4]string, bold, italic bool) {
+	for i, fam := range fontFamilies {
+		for j, n := range fam {
+			if n == name {
+				return &fontFamilies[i], j&1 != 0, j&2 != 0
+			}
+		}
+	}
+	return nil, false, false
+}
+
+// textRun is a part of a line of text that
+// is drawn with a single font.
+type textRun struct {
+	// font is the font of the run.
+	font vg.Font
+
+	// shift is the distance of the baseline of
+	// the run above the baseline of the line.
+	shift vg.Length
+
+	// text is the text of the run.
+	text string
+
+	// math is the layout of the run if it is
+	// a formula, in which case text holds its
+	// TeX source.
+	math *mathBox
+}
+
+// width returns the width of the run.
+func (r textRun) width() vg.Length {
+	if r.math != nil {
+		return r.math.width
+	}
+	return r.font.Width(r.text)
+}
+
+// ascent and descent return the extents of the run
+// above and below its baseline.
+func (r textRun) ascent() vg.Length {
+	if r.math != nil {
+		return r.math.height
+	}
+	return r.font.Extents().Ascent
+}
+
+func (r textRun) descent() vg.Length {
+	if r.math != nil {
+		return r.math.depth
+	}
+	return -r.font.Extents().Descent
+}
+
+// runsWidth returns the width of the runs.
+func runsWidth(runs []textRun) vg.Length {
+	var w vg.Length
+	for _, r := range runs {
+		w += r.width()
+	}
+	return w
+}
+
+// runStyle is the style of the text
+// added by the markup parser.
+type runStyle struct {
+	font  vg.Font
+	shift vg.Length
+}
+
+// script returns the style of a superscript,
+// or of a subscript if sup is false.
+func (s runStyle) script(sup bool) runStyle {
+	if sup {
+		s.shift += supShift * s.font.Size
+	} else {
+		s.shift -= subShift * s.font.Size
+	}
+	s.font.Size *= scriptScale
+	return s
+}
+
+// variant returns the style with the bold or italic variant
+// of its font. Fonts without variants are left unchanged.
+func (s runStyle) variant(bold, italic bool) runStyle {
+	fam, b, i := fontVariant(s.font.Name())
+	if fam == nil {
+		return s
+	}
+	j := 0
+	if b || bold {
+		j |= 1
+	}
+	if i || italic {
+		j |= 2
+	}
+	fnt := s.font
+	if err := fnt.SetName(fam[j]); err == nil {
+		s.font = fnt
+	}
+	return s
+}
+
+// markupRuns returns the runs of a line of text marked up as
+// described by the documentation of TextStyle, where fnt is the
+// font of the line. Malformed markup is drawn as written, and
+// groups that are left open are closed at the end of the line.
+func markupRuns(fnt vg.Font, line string) []textRun {
+	p := markupParser{line: line}
+	p.parse(runStyle{font: fnt}, false)
+	return p.runs
+}
+
+// markupParser is a parser of the markup of a line of text.
+type markupParser struct {
+	line string
+	pos  int
+	runs []textRun
+}
+
+// done returns whether the whole line has been parsed.
+func (p *markupParser) done() bool {
+	return p.pos >= len(p.line)
+}
+
+// peek returns the next rune of the line without consuming it.
+func (p *markupParser) peek() rune {
+	r, _ := utf8.DecodeRuneInString(p.line[p.pos:])
+	return r
+}
+
+// next consumes and returns the next rune of the line.
+func (p *markupParser) next() rune {
+	r, n := utf8.DecodeRuneInString(p.line[p.pos:])
+	p.pos += n
+	return r
+}
+
+// parse parses the line in the style sty up to its end,
+// or up to the closing brace of a group if group is true.
+func (p *markupParser) parse(sty runStyle, group bool) {
+	for !p.done() {
+		if group && p.peek() == '}' {
+			p.next()
+			return
+		}
+		p.atom(sty)
+	}
+}
+
+// atom parses a single element of the line in the style sty:
+// a group in braces, a script, a command, a formula between
+// dollar signs or a single rune.
+func (p *markupParser) atom(sty runStyle) {
+	switch r := p.next(); r {
+	case '{':
+		p.parse(sty, true)
+	case '^', '_':
+		if p.done() || p.peek() == '}' {
+			p.add(sty, string(r))
+			return
+		}
+		p.atom(sty.script(r == '^'))
+	case '\\':
+		p.command(sty)
+	case '$':
+		start := p.pos
+		for !p.done() && p.peek() != '$' {
+			if p.next() == '\\' && !p.done() {
+				p.next()
+			}
+		}
+		src := p.line[start:p.pos]
+		if !p.done() {
+			p.next()
+		}
+		p.runs = append(p.runs, textRun{font: sty.font, shift: sty.shift, text: src, math: layoutMath(sty.font, src)})
+	default:
+		p.add(sty, string(r))
+	}
+}
+
+// command parses a command following a backslash in the style
+// sty. Unknown commands are added to the runs as written.
+func (p *markupParser) command(sty runStyle) {
+	start := p.pos
+	for !p.done() && unicode.IsLetter(p.peek()) {
+		p.next()
+	}
+	name := p.line[start:p.pos]
+	switch {
+	case name == "" && !p.done() && strings.ContainsRune(`\{}^_$`, p.peek()):
+		p.add(sty, string(p.next()))
+	case name == "textbf" && !p.done():
+		p.atom(sty.variant(true, false))
+	case name == "textit" && !p.done():
+		p.atom(sty.variant(false, true))
+	default:
+		p.add(sty, `\`+name)
+	}
+}
+
+// add adds the text s in the style sty to the runs,
+// extending the last run if it has the same style.
+func (p *markupParser) add(sty runStyle, s string) {
+	if n := len(p.runs); n != 0 {
+		last := &p.runs[n-1]
+		if last.math == nil && last.font == sty.font && last.shift == sty.shift {
+			last.text += s
+			return
+		}
+	}
+	p.runs = append(p.runs, textRun{font: sty.font, shift: sty.shift, text: s})
+}
@@ -0,0 +1,135 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/recorder"
+)
+
+func TestMarkupRuns(t *testing.T) {
+	fnt, err := vg.MakeFont("Helvetica", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// run formats a run as its font name, size, shift and text.
+	run := func(name string, size, shift vg.Length, text string) string {
+		return fmt.Sprintf("%s %.4g %.4g %q", name, size, shift, text)
+	}
+	for _, test := range []struct {
+		line string
+		want []string
+	}{
+		{
+			line: "m/s^2",
+			want: []string{
+				run("Helvetica", 10, 0, "m/s"),
+				run("Helvetica", 7, 4, "2"),
+			},
+		},
+		{
+			line: "H_{2}O",
+			want: []string{
+				run("Helvetica", 10, 0, "H"),
+				run("Helvetica", 7, -2, "2"),
+				run("Helvetica", 10, 0, "O"),
+			},
+		},
+		{
+			line: "e^{x^2}",
+			want: []string{
+				run("Helvetica", 10, 0, "e"),
+				run("Helvetica", 7, 4, "x"),
+				run("Helvetica", 4.9, 6.8, "2"),
+			},
+		},
+		{
+			line: `a \textbf{bold \textit{move}} here`,
+			want: []string{
+				run("Helvetica", 10, 0, "a "),
+				run("Helvetica-Bold", 10, 0, "bold "),
+				run("Helvetica-BoldOblique", 10, 0, "move"),
+				run("Helvetica", 10, 0, " here"),
+			},
+		},
+		{
+			line: `x\_1 \{y\} \\ \alpha`,
+			want: []string{
+				run("Helvetica", 10, 0, `x_1 {y} \ \alpha`),
+			},
+		},
+		{
+			line: "x^ {unclosed",
+			want: []string{
+				run("Helvetica", 10, 0, "x"),
+				run("Helvetica", 7, 4, " "),
+				run("Helvetica", 10, 0, "unclosed"),
+			},
+		},
+		{
+			line: "}^",
+			want: []string{
+				run("Helvetica", 10, 0, "}^"),
+			},
+		},
+	} {
+		var got []string
+		for _, r := range markupRuns(fnt, test.line) {
+			got = append(got, run(r.font.Name(), r.font.Size, r.shift, r.text))
+		}
+		if fmt.Sprint(got) != fmt.Sprint(test.want) {
+			t.Errorf("unexpected runs for %q:\ngot: %q\nwant:%q", test.line, got, test.want)
+		}
+	}
+}
+
+func TestTextStyleMarkup(t *testing.T) {
+	fnt, err := vg.MakeFont("Times-Roman", 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain := TextStyle{Font: fnt}
+	marked := TextStyle{Font: fnt, Markup: true}
+
+	if got, want := marked.Width("x"), plain.Width("x"); got != want {
+		t.Errorf("unexpected width of text without markup: got:%v want:%v", got, want)
+	}
+	if got, want := marked.Width("x^{2}"), plain.Width("x^{2}"); got >= want {
+		t.Errorf("unexpected width of marked up text: got:%v want less than %v", got, want)
+	}
+	if got, want := marked.Height("x_2\ny"), plain.Height("x_2\ny"); got != want {
+		t.Errorf("unexpected height of subscript: got:%v want:%v", got, want)
+	}
+	if got, want := marked.Height("x^2\ny"), plain.Height("x^2\ny"); got <= want {
+		t.Errorf("unexpected height of superscript: got:%v want more than %v", got, want)
+	}
+
+	var rec recorder.Canvas
+	c := NewCanvas(&rec, 100, 100)
+	c.FillText(marked, vg.Point{X: 10, Y: 10}, `\textit{v}_{max}`)
+	var strs []*recorder.FillString
+	for _, a := range rec.Actions {
+		if a, ok := a.(*recorder.FillString); ok {
+			strs = append(strs, a)
+		}
+	}
+	if len(strs) != 2 {
+		t.Fatalf("unexpected number of strings: got:%d want:2", len(strs))
+	}
+	if got := strs[0].Font; got != "Times-Italic" {
+		t.Errorf("unexpected font of first string: got:%q want:%q", got, "Times-Italic")
+	}
+	it, err := vg.MakeFont("Times-Italic", 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, max := strs[0].Point, strs[1].Point
+	if want := v.X + it.Width("v"); max.X != want || max.Y >= v.Y {
+		t.Errorf("unexpected position of subscript: got:%+v want X:%v and Y below %v", max, want, v.Y)
+	}
+}
@@ -0,0 +1,986 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/gshk/plot/vg"
+)
+
+// Sizes in math are given in em of the font of the current style.
+const (
+	// scriptScriptScale is the size of scripts of
+	// scripts relative to the size of the formula.
+	scriptScriptScale = 0.5
+
+	// bigOpScale is the size of big operators
+	// relative to the size of the current style.
+	bigOpScale = 1.3
+
+	// ruleThickness is the thickness of
+	// fraction bars and of radicals.
+	ruleThickness = 0.05
+)
+
+// mathItem is a piece of text, an outline or
+// a stroked path in a mathBox.
+type mathItem struct {
+	// pos is the position of the item in the box. Text starts
+	// at pos on its baseline and paths are relative to pos.
+	pos vg.Point
+
+	// font and text are the text of the item.
+	// The item is a path if text is empty.
+	font vg.Font
+	text string
+
+	// path is filled, or stroked with the
+	// width stroke if stroke is not zero.
+	path   vg.Path
+	stroke vg.Length
+}
+
+// mathBox is a laid out part of a formula, with
+// its origin at the left end of its baseline.
+type mathBox struct {
+	// width is the width of the box, and height and
+	// depth are the extents of the box above and
+	// below its baseline.
+	width, height, depth vg.Length
+
+	// items are the parts of the box.
+	items []mathItem
+}
+
+// place adds the items of the box c to b with the
+// origin of c at pt. The extents of b are unchanged.
+func (b *mathBox) place(c *mathBox, pt vg.Point) {
+	for _, it := range c.items {
+		it.pos = it.pos.Add(pt)
+		b.items = append(b.items, it)
+	}
+}
+
+// rule adds a rule filling the rectangle r to b.
+// The extents of b are unchanged.
+func (b *mathBox) rule(r vg.Rectangle) {
+	b.items = append(b.items, mathItem{pos: r.Min, path: vg.Rectangle{Max: r.Size()}.Path()})
+}
+
+// draw draws the box on c with its origin at pt.
+func (b *mathBox) draw(c *Canvas, pt vg.Point) {
+	for _, it := range b.items {
+		at := pt.Add(it.pos)
+		switch {
+		case it.text != "":
+			c.FillString(it.font, at, it.text)
+		case it.stroke != 0:
+			c.Push()
+			c.SetLineWidth(it.stroke)
+			c.SetLineDash(nil, 0)
+			c.SetLineCap(vg.RoundCap)
+			c.SetLineJoin(vg.RoundJoin)
+			c.Stroke(mapPath(it.path, func(p vg.Point) vg.Point { return p.Add(at) }))
+			c.Pop()
+		default:
+			c.Fill(mapPath(it.path, func(p vg.Point) vg.Point { return p.Add(at) }))
+		}
+	}
+}
+
+// mapPath returns the path p with f applied to its points.
+func mapPath(p vg.Path, f func(vg.Point) vg.Point) vg.Path {
+	q := make(vg.Path, len(p))
+	for i, comp := range p {
+		comp.Pos = f(comp.Pos)
+		if comp.Control != nil {
+			ctrl := make([]vg.Point, len(comp.Control))
+			for j, pt := range comp.Control {
+				ctrl[j] = f(pt)
+			}
+			comp.Control = ctrl
+		}
+		q[i] = comp
+	}
+	return q
+}
+
+// mathGlyph is the outline of a glyph with its metrics.
+type mathGlyph struct {
+	// path is the outline of the glyph relative
+	// to the left end of its baseline.
+	path vg.Path
+
+	// advance is the advance width of the glyph,
+	// and min and max are the corners of its ink.
+	advance  vg.Length
+	min, max vg.Point
+}
+
+// loadGlyph returns the glyph of the rune r in the font fnt.
+func loadGlyph(fnt vg.Font, r rune) mathGlyph {
+	f := fnt.Font()
+	upem := f.FUnitsPerEm()
+	var buf truetype.GlyphBuf
+	if err := buf.Load(f, fixed.Int26_6(upem), f.Index(r), font.HintingNone); err != nil {
+		return mathGlyph{}
+	}
+	scale := fnt.Size / vg.Length(upem)
+	pt := func(x, y fixed.Int26_6) vg.Point {
+		return vg.Point{X: vg.Length(x) * scale, Y: vg.Length(y) * scale}
+	}
+
+	g := mathGlyph{
+		advance: vg.Length(buf.AdvanceWidth) * scale,
+		min:     pt(buf.Bounds.Min.X, buf.Bounds.Min.Y),
+		max:     pt(buf.Bounds.Max.X, buf.Bounds.Max.Y),
+	}
+	start := 0
+	for _, end := range buf.Ends {
+		g.path = addContour(g.path, buf.Points[start:end], pt)
+		start = end
+	}
+	return g
+}
+
+// addContour adds the closed TrueType contour c of quadratic curves
+// to the path p, converting its points with pt.
+func addContour(p vg.Path, c []truetype.Point, pt func(x, y fixed.Int26_6) vg.Point) vg.Path {
+	if len(c) == 0 {
+		return p
+	}
+	on := func(q truetype.Point) bool { return q.Flags&1 != 0 }
+
+	// The contour starts at its first point on the curve, or
+	// between its last and first points if none is on the curve.
+	first := -1
+	for i, q := range c {
+		if on(q) {
+			first = i
+			break
+		}
+	}
+	var start vg.Point
+	var rest []truetype.Point
+	if first < 0 {
+		a, b := pt(c[0].X, c[0].Y), pt(c[len(c)-1].X, c[len(c)-1].Y)
+		start = a.Add(b).Scale(0.5)
+		rest = c
+	} else {
+		start = pt(c[first].X, c[first].Y)
+		rest = append(append([]truetype.Point(nil), c[first+1:]...), c[:first]...)
+	}
+
+	p.Move(start)
+	var (
+		ctrl    vg.Point
+		pending bool
+	)
+	for _, q := range rest {
+		cur := pt(q.X, q.Y)
+		switch {
+		case on(q) && pending:
+			p.QuadTo(ctrl, cur)
+			pending = false
+		case on(q):
+			p.Line(cur)
+		case pending:
+			p.QuadTo(ctrl, ctrl.Add(cur).Scale(0.5))
+			ctrl = cur
+		default:
+			ctrl, pending = cur, true
+		}
+	}
+	if pending {
+		p.QuadTo(ctrl, start)
+	}
+	p.Close()
+	return p
+}
+
+// textBox returns a box holding the text s in the font fnt.
+// Printable ASCII text is drawn with FillString, and other
+// characters are drawn as outlines so that they look the
+// same on all canvases.
+func textBox(fnt vg.Font, s string) *mathBox {
+	b := &mathBox{}
+	var ascii strings.Builder
+	flush := func() {
+		if ascii.Len() == 0 {
+			return
+		}
+		b.items = append(b.items, mathItem{pos: vg.Point{X: b.width}, font: fnt, text: ascii.String()})
+		b.width += fnt.Width(ascii.String())
+		ascii.Reset()
+	}
+	for _, r := range s {
+		g := loadGlyph(fnt, r)
+		b.height = max(b.height, g.max.Y)
+		b.depth = max(b.depth, -g.min.Y)
+		if ' ' <= r && r <= '~' {
+			ascii.WriteRune(r)
+			continue
+		}
+		flush()
+		b.items = append(b.items, mathItem{pos: vg.Point{X: b.width}, path: g.path})
+		b.width += g.advance
+	}
+	flush()
+	return b
+}
+
+// mathClass is the class of an atom of a formula,
+// which sets the spaces around it.
+type mathClass int
+
+const (
+	mathOrd mathClass = iota
+	mathOp
+	mathBin
+	mathRel
+	mathOpen
+	mathClose
+	mathPunct
+)
+
+// mathSpacing holds the spaces in eighteenths of an em
+// between atoms of the classes indexing it, as in TeX.
+// Negative spaces are left out in scripts.
+var mathSpacing = [...][7]int{
+	mathOrd:   {0, 3, -4, -5, 0, 0, 0},
+	mathOp:    {3, 3, 0, -5, 0, 0, 0},
+	mathBin:   {-4, -4, 0, 0, -4, 0, 0},
+	mathRel:   {-5, -5, 0, 0, -5, 0, 0},
+	mathOpen:  {0, 0, 0, 0, 0, 0, 0},
+	mathClose: {0, 3, -4, -5, 0, 0, 0},
+	mathPunct: {-3, -3, 0, -3, -3, -3, -3},
+}
+
+// mathAtom is an element of a formula.
+type mathAtom struct {
+	box   *mathBox
+	class mathClass
+
+	// char is whether the atom is a single character,
+	// which takes scripts at fixed distances.
+	char bool
+
+	// kern is whether the atom is a space,
+	// which is ignored when spacing atoms.
+	kern bool
+}
+
+// mathShape is the shape of the characters of a formula.
+type mathShape int
+
+const (
+	// mathNormal draws letters in italics
+	// and other characters upright.
+	mathNormal mathShape = iota
+	mathRoman
+	mathBold
+	mathItalic
+)
+
+// mathStyle is the style in which a part
+// of a formula is laid out.
+type mathStyle struct {
+	// font is the upright font of the style.
+	font vg.Font
+
+	// size is the size of the formula, and level
+	// is 0 in the formula, 1 in its scripts and
+	// 2 in scripts of scripts.
+	size  vg.Length
+	level int
+
+	shape mathShape
+}
+
+// em returns the length x in em of the style.
+func (s mathStyle) em(x float64) vg.Length {
+	return vg.Length(x) * s.font.Size
+}
+
+// script returns the style of scripts in s.
+func (s mathStyle) script() mathStyle {
+	if s.level < 2 {
+		s.level++
+	}
+	if s.level == 1 {
+		s.font.Size = scriptScale * s.size
+	} else {
+		s.font.Size = scriptScriptScale * s.size
+	}
+	return s
+}
+
+// charFont returns the font of the character r in the style.
+func (s mathStyle) charFont(r rune) vg.Font {
+	switch s.shape {
+	case mathRoman:
+		return s.font
+	case mathBold:
+		return runStyle{font: s.font}.variant(true, false).font
+	case mathItalic:
+		return runStyle{font: s.font}.variant(false, true).font
+	}
+	if 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || 'α' <= r && r <= 'ω' {
+		return runStyle{font: s.font}.variant(false, true).font
+	}
+	return s.font
+}
+
+// axis returns the height of the math axis above the baseline,
+// on which fractions and big operators are centered.
+func (s mathStyle) axis() vg.Length {
+	g := loadGlyph(s.font, '+')
+	return (g.min.Y + g.max.Y) / 2
+}
+
+// mathGreek maps the names of Greek letters to the letters.
+var mathGreek = map[string]rune{
+	"alpha": 'α', "beta": 'β', "gamma": 'γ', "delta": 'δ',
+	"epsilon": 'ε', "varepsilon": 'ε', "zeta": 'ζ', "eta": 'η',
+	"theta": 'θ', "iota": 'ι', "kappa": 'κ', "lambda": 'λ',
+	"mu": 'μ', "nu": 'ν', "xi": 'ξ', "pi": 'π', "rho": 'ρ',
+	"sigma": 'σ', "varsigma": 'ς', "tau": 'τ', "upsilon": 'υ',
+	"phi": 'φ', "varphi": 'φ', "chi": 'χ', "psi": 'ψ', "omega": 'ω',
+
+	"Gamma": 'Γ', "Delta": 'Δ', "Theta": 'Θ', "Lambda": 'Λ',
+	"Xi": 'Ξ', "Pi": 'Π', "Sigma": 'Σ', "Upsilon": 'Υ',
+	"Phi": 'Φ', "Psi": 'Ψ', "Omega": 'Ω',
+}
+
+// mathSymbols maps the names of symbols
+// to the symbols and their classes.
+var mathSymbols = map[string]struct {
+	sym   string
+	class mathClass
+}{
+	"infty": {"∞", mathOrd}, "partial": {"∂", mathOrd},
+	"hbar": {"ħ", mathOrd}, "ell": {"ℓ", mathOrd},
+	"prime": {"′", mathOrd}, "neg": {"¬", mathOrd},
+	"ldots": {"…", mathOrd}, "dots": {"…", mathOrd},
+	"cdots": {"···", mathOrd}, "vert": {"|", mathOrd},
+	"pm": {"±", mathBin}, "times": {"×", mathBin},
+	"div": {"÷", mathBin}, "cdot": {"·", mathBin},
+	"ast": {"*", mathBin}, "cap": {"∩", mathBin},
+	"leq": {"≤", mathRel}, "le": {"≤", mathRel},
+	"geq": {"≥", mathRel}, "ge": {"≥", mathRel},
+	"neq": {"≠", mathRel}, "ne": {"≠", mathRel},
+	"approx": {"≈", mathRel}, "equiv": {"≡", mathRel},
+	"sim": {"~", mathRel}, "mid": {"|", mathRel},
+	"to": {"→", mathRel}, "rightarrow": {"→", mathRel},
+	"gets": {"←", mathRel}, "leftarrow": {"←", mathRel},
+	"uparrow": {"↑", mathRel}, "downarrow": {"↓", mathRel},
+	"leftrightarrow": {"↔", mathRel},
+}
+
+// mathBigOps maps the names of big operators to the operators.
+var mathBigOps = map[string]rune{
+	"sum": '∑', "prod": '∏', "int": '∫',
+}
+
+// mathFuncs are the names of functions
+// that are set upright as operators.
+var mathFuncs = map[string]bool{
+	"arccos": true, "arcsin": true, "arctan": true, "arg": true,
+	"cos": true, "cosh": true, "cot": true, "coth": true,
+	"csc": true, "deg": true, "det": true, "dim": true,
+	"exp": true, "gcd": true, "inf": true, "ker": true,
+	"lg": true, "lim": true, "ln": true, "log": true,
+	"max": true, "min": true, "Pr": true, "sec": true,
+	"sin": true, "sinh": true, "sup": true, "tan": true,
+	"tanh": true,
+}
+
+// mathAccents maps the names of accents to their glyphs.
+var mathAccents = map[string]rune{
+	"hat": 'ˆ', "check": 'ˇ', "tilde": '˜', "acute": '´',
+	"grave": '`', "dot": '˙', "ddot": '¨', "breve": '˘',
+	"bar": '¯', "vec": '→',
+}
+
+// mathShapes maps the names of font commands to their shapes.
+var mathShapes = map[string]mathShape{
+	"mathnormal": mathNormal, "mathrm": mathRoman,
+	"mathbf": mathBold, "mathit": mathItalic,
+}
+
+// mathSpaces maps the names of spacing commands
+// to their widths in eighteenths of an em.
+var mathSpaces = map[string]int{
+	",": 3, ":": 4, ">": 4, ";": 5, "!": -3,
+	"quad": 18, "qquad": 36,
+}
+
+// layoutMath returns the TeX math in src laid out in the font fnt.
+// Malformed math is laid out as far as possible, with groups that
+// are left open closed at the end of the formula and unknown commands
+// drawn as written.
+func layoutMath(fnt vg.Font, src string) *mathBox {
+	p := mathParser{src: src}
+	return p.list(mathStyle{font: fnt, size: fnt.Size}, mathEndSource)
+}
+
+// mathEnd is the end of a list of atoms in a formula.
+type mathEnd int
+
+const (
+	mathEndSource mathEnd = iota // The end of the formula.
+	mathEndBrace                 // A closing brace.
+	mathEndRight                 // A \right command.
+)
+
+// mathParser is a parser of TeX math.
+type mathParser struct {
+	src string
+	pos int
+}
+
+// done returns whether the whole formula has been parsed.
+func (p *mathParser) done() bool {
+	return p.pos >= len(p.src)
+}
+
+// peek returns the next byte of the formula without consuming it.
+func (p *mathParser) peek() byte {
+	return p.src[p.pos]
+}
+
+// next consumes and returns the next rune of the formula.
+func (p *mathParser) next() rune {
+	r, n := utf8.DecodeRuneInString(p.src[p.pos:])
+	p.pos += n
+	return r
+}
+
+// skipSpace skips spaces, which are ignored in math.
+func (p *mathParser) skipSpace() {
+	for !p.done() && strings.IndexByte(" \t", p.peek()) >= 0 {
+		p.pos++
+	}
+}
+
+// at returns whether the formula continues with the command cmd.
+func (p *mathParser) at(cmd string) bool {
+	rest := p.src[p.pos:]
+	return strings.HasPrefix(rest, cmd) && (len(rest) == len(cmd) || !isLetter(rest[len(cmd)]))
+}
+
+// isLetter returns whether b is an ASCII letter.
+func isLetter(b byte) bool {
+	return 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z'
+}
+
+// list parses atoms up to the given end and lays them out in a row.
+func (p *mathParser) list(sty mathStyle, end mathEnd) *mathBox {
+	var atoms []mathAtom
+	for {
+		p.skipSpace()
+		if p.done() {
+			break
+		}
+		if end == mathEndBrace && p.peek() == '}' {
+			p.pos++
+			break
+		}
+		if end == mathEndRight && p.at(`\right`) {
+			break
+		}
+		var a mathAtom
+		if c := p.peek(); c == '^' || c == '_' {
+			a = mathAtom{box: &mathBox{}}
+		} else {
+			var ok bool
+			a, ok = p.atom(sty)
+			if !ok {
+				continue
+			}
+		}
+		atoms = append(atoms, p.scripts(a, sty))
+	}
+	return hlist(atoms, sty)
+}
+
+// atom parses a single atom. It returns false if the
+// parsed text does not lay out anything.
+func (p *mathParser) atom(sty mathStyle) (mathAtom, bool) {
+	r := p.next()
+	switch r {
+	case '{':
+		return mathAtom{box: p.list(sty, mathEndBrace)}, true
+	case '\\':
+		return p.command(sty)
+	case '~':
+		return mathAtom{box: &mathBox{width: sty.font.Width(" ")}, kern: true}, true
+	case '\'':
+		return p.char(sty, '′', mathOrd), true
+	case '-':
+		return p.char(sty, '−', mathBin), true
+	case '+', '*':
+		return p.char(sty, r, mathBin), true
+	case '=', '<', '>', ':':
+		return p.char(sty, r, mathRel), true
+	case ',', ';':
+		return p.char(sty, r, mathPunct), true
+	case '(', '[':
+		return p.char(sty, r, mathOpen), true
+	case ')', ']', '!', '?':
+		return p.char(sty, r, mathClose), true
+	}
+	return p.char(sty, r, mathOrd), true
+}
+
+// char returns an atom of the class holding the character r.
+func (p *mathParser) char(sty mathStyle, r rune, class mathClass) mathAtom {
+	return mathAtom{box: textBox(sty.charFont(r), string(r)), class: class, char: true}
+}
+
+// command parses a command following a backslash.
+func (p *mathParser) command(sty mathStyle) (mathAtom, bool) {
+	start := p.pos
+	for !p.done() && isLetter(p.peek()) {
+		p.pos++
+	}
+	name := p.src[start:p.pos]
+	if name == "" && !p.done() {
+		name = string(p.next())
+	}
+
+	if w, ok := mathSpaces[name]; ok {
+		return mathAtom{box: &mathBox{width: sty.em(float64(w) / 18)}, kern: true}, true
+	}
+	if r, ok := mathGreek[name]; ok {
+		return p.char(sty, r, mathOrd), true
+	}
+	if s, ok := mathSymbols[name]; ok {
+		return mathAtom{box: textBox(sty.font, s.sym), class: s.class, char: true}, true
+	}
+	if r, ok := mathBigOps[name]; ok {
+		return mathAtom{box: bigOperator(r, sty), class: mathOp}, true
+	}
+	if mathFuncs[name] {
+		return mathAtom{box: textBox(sty.font, name), class: mathOp}, true
+	}
+	if r, ok := mathAccents[name]; ok {
+		base := p.argument(sty)
+		fnt := sty.font
+		if name == "vec" {
+			fnt.Size *= scriptScale
+		}
+		return mathAtom{box: accent(base, loadGlyph(fnt, r), sty)}, true
+	}
+	if shape, ok := mathShapes[name]; ok {
+		sty.shape = shape
+		return mathAtom{box: p.argument(sty)}, true
+	}
+
+	switch name {
+	case " ":
+		return mathAtom{box: &mathBox{width: sty.font.Width(" ")}, kern: true}, true
+	case "{":
+		return p.char(sty, '{', mathOpen), true
+	case "}":
+		return p.char(sty, '}', mathClose), true
+	case "$", "%", "#", "&", "_", `\`:
+		return p.char(sty, rune(name[0]), mathOrd), true
+	case "text", "textrm", "mbox":
+		return mathAtom{box: textBox(sty.font, p.rawArgument())}, true
+	case "textbf":
+		return mathAtom{box: textBox(runStyle{font: sty.font}.variant(true, false).font, p.rawArgument())}, true
+	case "textit":
+		return mathAtom{box: textBox(runStyle{font: sty.font}.variant(false, true).font, p.rawArgument())}, true
+	case "operatorname":
+		sty.shape = mathRoman
+		return mathAtom{box: p.argument(sty), class: mathOp}, true
+	case "frac", "dfrac":
+		inner := sty
+		if name == "frac" {
+			inner = sty.script()
+		}
+		num := p.argument(inner)
+		den := p.argument(inner)
+		return mathAtom{box: fraction(num, den, sty)}, true
+	case "sqrt":
+		var index *mathBox
+		if p.skipSpace(); !p.done() && p.peek() == '[' {
+			p.pos++
+			src := p.src[p.pos:]
+			if end := strings.IndexByte(src, ']'); end >= 0 {
+				src = src[:end]
+				p.pos++
+			}
+			p.pos += len(src)
+			q := mathParser{src: src}
+			index = q.list(sty.script().script(), mathEndSource)
+		}
+		return mathAtom{box: radical(p.argument(sty), index, sty)}, true
+	case "overline", "underline":
+		return mathAtom{box: line(p.argument(sty), name == "overline", sty)}, true
+	case "left":
+		left := p.delimiter()
+		inner := p.list(sty, mathEndRight)
+		var right string
+		if p.at(`\right`) {
+			p.pos += len(`\right`)
+			right = p.delimiter()
+		}
+		return mathAtom{box: delimited(inner, left, right, sty)}, true
+	case "right":
+		p.delimiter()
+		return mathAtom{}, false
+	}
+	return mathAtom{box: textBox(sty.font, `\`+name)}, true
+}
+
+// argument parses the argument of a command, which is a group in
+// braces or a single atom.
+func (p *mathParser) argument(sty mathStyle) *mathBox {
+	p.skipSpace()
+	if p.done() || p.peek() == '}' {
+		return &mathBox{}
+	}
+	if p.peek() == '{' {
+		p.pos++
+		return p.list(sty, mathEndBrace)
+	}
+	a, ok := p.atom(sty)
+	if !ok {
+		return &mathBox{}
+	}
+	return a.box
+}
+
+// rawArgument returns the text of the argument of a command, which
+// is a group in braces or a single rune, without parsing it.
+func (p *mathParser) rawArgument() string {
+	p.skipSpace()
+	if p.done() {
+		return ""
+	}
+	if p.peek() != '{' {
+		return string(p.next())
+	}
+	p.pos++
+	start, depth := p.pos, 0
+	for !p.done() {
+		switch p.next() {
+		case '\\':
+			if !p.done() {
+				p.next()
+			}
+		case '{':
+			depth++
+		case '}':
+			if depth == 0 {
+				return p.src[start : p.pos-1]
+			}
+			depth--
+		}
+	}
+	return p.src[start:]
+}
+
+// delimiter parses the delimiter following \left or \right.
+// The empty delimiter "." is returned as an empty string.
+func (p *mathParser) delimiter() string {
+	p.skipSpace()
+	if p.done() {
+		return ""
+	}
+	r := p.next()
+	switch r {
+	case '.':
+		return ""
+	case '\\':
+		start := p.pos
+		for !p.done() && isLetter(p.peek()) {
+			p.pos++
+		}
+		switch name := p.src[start:p.pos]; name {
+		case "":
+			if p.done() {
+				return ""
+			}
+			return string(p.next())
+		case "vert", "mid":
+			return "|"
+		case "lbrace":
+			return "{"
+		case "rbrace":
+			return "}"
+		default:
+			return ""
+		}
+	}
+	return string(r)
+}
+
+// scripts parses the superscript and the subscript
+// of the atom a, if any, and attaches them to a.
+func (p *mathParser) scripts(a mathAtom, sty mathStyle) mathAtom {
+	var sup, sub *mathBox
+loop:
+	for {
+		p.skipSpace()
+		if p.done() {
+			break
+		}
+		switch p.peek() {
+		case '^':
+			if sup != nil {
+				break loop
+			}
+			p.pos++
+			sup = p.argument(sty.script())
+		case '_':
+			if sub != nil {
+				break loop
+			}
+			p.pos++
+			sub = p.argument(sty.script())
+		default:
+			break loop
+		}
+	}
+	if sup == nil && sub == nil {
+		return a
+	}
+
+	nuc := a.box
+	var u, v vg.Length
+	if a.char {
+		u, v = sty.em(supShift), sty.em(subShift)
+	} else {
+		u = max(sty.em(supShift), nuc.height-sty.em(0.3))
+		v = max(sty.em(subShift), nuc.depth+sty.em(0.1))
+	}
+	b := &mathBox{width: nuc.width, height: nuc.height, depth: nuc.depth}
+	b.place(nuc, vg.Point{})
+	if sup != nil && sub != nil {
+		// Keep a gap between the scripts.
+		if gap := (u - sup.depth) - (sub.height - v); gap < sty.em(0.1) {
+			v += sty.em(0.1) - gap
+		}
+	}
+	var w vg.Length
+	if sup != nil {
+		b.place(sup, vg.Point{X: nuc.width, Y: u})
+		b.height = max(b.height, u+sup.height)
+		w = sup.width
+	}
+	if sub != nil {
+		b.place(sub, vg.Point{X: nuc.width, Y: -v})
+		b.depth = max(b.depth, v+sub.depth)
+		w = max(w, sub.width)
+	}
+	b.width += w + sty.em(0.05)
+	a.box, a.char = b, false
+	return a
+}
+
+// hlist lays out the atoms in a row with the spaces
+// between them given by their classes.
+func hlist(atoms []mathAtom, sty mathStyle) *mathBox {
+	// Binary operators without an operand on
+	// either side are ordinary atoms.
+	prev := -1
+	for i := range atoms {
+		a := &atoms[i]
+		if a.kern {
+			continue
+		}
+		if a.class == mathBin {
+			if prev < 0 {
+				a.class = mathOrd
+			} else {
+				switch atoms[prev].class {
+				case mathBin, mathOp, mathRel, mathOpen, mathPunct:
+					a.class = mathOrd
+				}
+			}
+		}
+		if prev >= 0 && atoms[prev].class == mathBin {
+			switch a.class {
+			case mathRel, mathClose, mathPunct:
+				atoms[prev].class = mathOrd
+			}
+		}
+		prev = i
+	}
+	if prev >= 0 && atoms[prev].class == mathBin {
+		atoms[prev].class = mathOrd
+	}
+
+	b := &mathBox{}
+	prev = -1
+	for i, a := range atoms {
+		if !a.kern && prev >= 0 {
+			sp := mathSpacing[atoms[prev].class][a.class]
+			if sp < 0 {
+				if sty.level > 0 {
+					sp = 0
+				} else {
+					sp = -sp
+				}
+			}
+			b.width += sty.em(float64(sp) / 18)
+		}
+		b.place(a.box, vg.Point{X: b.width})
+		b.width += a.box.width
+		b.height = max(b.height, a.box.height)
+		b.depth = max(b.depth, a.box.depth)
+		if !a.kern {
+			prev = i
+		}
+	}
+	return b
+}
+
+// glyphBox returns a box holding the glyph g
+// with its baseline raised by y.
+func glyphBox(g mathGlyph, y vg.Length) *mathBox {
+	return &mathBox{
+		width:  g.advance,
+		height: g.max.Y + y,
+		depth:  -(g.min.Y + y),
+		items:  []mathItem{{pos: vg.Point{Y: y}, path: g.path}},
+	}
+}
+
+// bigOperator returns a box holding the big
+// operator r centered on the math axis.
+func bigOperator(r rune, sty mathStyle) *mathBox {
+	fnt := sty.font
+	fnt.Size *= bigOpScale
+	g := loadGlyph(fnt, r)
+	return glyphBox(g, sty.axis()-(g.min.Y+g.max.Y)/2)
+}
+
+// fraction returns a box holding the fraction
+// with the numerator num and the denominator den.
+func fraction(num, den *mathBox, sty mathStyle) *mathBox {
+	var (
+		t   = sty.em(ruleThickness)
+		a   = sty.axis()
+		gap = 1.5 * t
+		pad = sty.em(0.1)
+	)
+	w := max(num.width, den.width) + 2*pad
+	u := a + t/2 + gap + num.depth
+	v := a - t/2 - gap - den.height
+	b := &mathBox{width: w, height: u + num.height, depth: den.depth - v}
+	b.place(num, vg.Point{X: (w - num.width) / 2, Y: u})
+	b.place(den, vg.Point{X: (w - den.width) / 2, Y: v})
+	b.rule(vg.Rectangle{
+		Min: vg.Point{X: pad / 2, Y: a - t/2},
+		Max: vg.Point{X: w - pad/2, Y: a + t/2},
+	})
+	return b
+}
+
+// radical returns a box holding the square root of body,
+// or the root with the given index if index is not nil.
+func radical(body, index *mathBox, sty mathStyle) *mathBox {
+	t := sty.em(ruleThickness)
+	top := body.height + t + sty.em(0.1) + t
+	bottom := -body.depth - sty.em(0.05)
+	h := top - bottom
+	w := sty.em(0.45) + 0.1*h
+	tick := min(0.45*h, sty.em(0.5))
+
+	var x vg.Length
+	b := &mathBox{height: top, depth: t - bottom}
+	if index != nil {
+		x = max(0, index.width-w/2)
+		y := bottom + 0.6*h + index.depth
+		b.place(index, vg.Point{X: x + w/2 - index.width, Y: y})
+		b.height = max(b.height, y+index.height)
+	}
+
+	var sign vg.Path
+	sign.Move(vg.Point{X: x, Y: bottom + 0.8*tick})
+	sign.Line(vg.Point{X: x + 0.2*w, Y: bottom + tick})
+	sign.Line(vg.Point{X: x + 0.5*w, Y: bottom})
+	sign.Line(vg.Point{X: x + w, Y: top - t/2})
+	b.items = append(b.items, mathItem{path: sign, stroke: t})
+	var down vg.Path
+	down.Move(vg.Point{X: x + 0.2*w, Y: bottom + tick})
+	down.Line(vg.Point{X: x + 0.5*w, Y: bottom})
+	b.items = append(b.items, mathItem{path: down, stroke: 2 * t})
+
+	x += w
+	b.place(body, vg.Point{X: x + sty.em(0.05)})
+	b.width = x + body.width + sty.em(0.1)
+	b.rule(vg.Rectangle{
+		Min: vg.Point{X: x, Y: top - t},
+		Max: vg.Point{X: b.width, Y: top},
+	})
+	return b
+}
+
+// accent returns a box holding base with the
+// glyph g centered above it as an accent.
+func accent(base *mathBox, g mathGlyph, sty mathStyle) *mathBox {
+	y := base.height + sty.em(0.05) - g.min.Y
+	b := &mathBox{width: base.width, height: max(base.height, y+g.max.Y), depth: base.depth}
+	b.place(base, vg.Point{})
+	x := (base.width - g.min.X - g.max.X) / 2
+	b.items = append(b.items, mathItem{pos: vg.Point{X: x, Y: y}, path: g.path})
+	return b
+}
+
+// line returns a box holding base with a rule
+// drawn over it, or under it if over is false.
+func line(base *mathBox, over bool, sty mathStyle) *mathBox {
+	t := sty.em(ruleThickness)
+	b := &mathBox{width: base.width, height: base.height, depth: base.depth}
+	b.place(base, vg.Point{})
+	y := base.height + 2*t
+	if over {
+		b.height = y + 2*t
+	} else {
+		y = -base.depth - 3*t
+		b.depth = -y + t
+	}
+	b.rule(vg.Rectangle{Min: vg.Point{Y: y}, Max: vg.Point{X: base.width, Y: y + t}})
+	return b
+}
+
+// delimited returns a box holding inner between the left and
+// right delimiters, stretched to cover inner symmetrically about
+// the math axis. Empty delimiters leave a small space.
+func delimited(inner *mathBox, left, right string, sty mathStyle) *mathBox {
+	a := sty.axis()
+	half := max(inner.height-a, inner.depth+a) + sty.em(0.05)
+	delim := func(d string) *mathBox {
+		if d == "" {
+			return &mathBox{width: sty.em(0.12)}
+		}
+		r := []rune(d)[0]
+		g := loadGlyph(sty.font, r)
+		mid := (g.min.Y + g.max.Y) / 2
+		k := max(1, 2*half/(g.max.Y-g.min.Y))
+		g.path = mapPath(g.path, func(p vg.Point) vg.Point {
+			return vg.Point{X: p.X, Y: a + (p.Y-mid)*k}
+		})
+		g.min.Y = a + (g.min.Y-mid)*k
+		g.max.Y = a + (g.max.Y-mid)*k
+		return glyphBox(g, 0)
+	}
+	return hlist([]mathAtom{
+		{box: delim(left), class: mathOpen},
+		{box: inner},
+		{box: delim(right), class: mathClose},
+	}, sty)
+}
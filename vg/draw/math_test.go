@@ -0,0 +1,97 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package draw
+
+import (
+	"testing"
+
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/recorder"
+)
+
+func TestLayoutMath(t *testing.T) {
+	fnt, err := vg.MakeFont("Times-Roman", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	it, err := vg.MakeFont("Times-Italic", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x := layoutMath(fnt, "x")
+	if got, want := x.width, it.Width("x"); got != want {
+		t.Errorf("unexpected width of variable: got:%v want:%v", got, want)
+	}
+	if got, want := layoutMath(fnt, "-x").width, layoutMath(fnt, "a-b").width-it.Width("a")-it.Width("b")+it.Width("x"); got >= want {
+		t.Errorf("unexpected width of unary minus: got:%v want less than %v", got, want)
+	}
+
+	frac := layoutMath(fnt, `\frac{1}{2}`)
+	if frac.height <= fnt.Extents().Ascent*scriptScale || frac.depth <= 0 {
+		t.Errorf("unexpected extents of fraction: height:%v depth:%v", frac.height, frac.depth)
+	}
+	if got, want := layoutMath(fnt, "x^2").height, x.height; got <= want {
+		t.Errorf("unexpected height of superscript: got:%v want more than %v", got, want)
+	}
+	if got, want := layoutMath(fnt, "x_i").depth, x.depth; got <= want {
+		t.Errorf("unexpected depth of subscript: got:%v want more than %v", got, want)
+	}
+	if got, want := layoutMath(fnt, `\sqrt{x}`).height, x.height; got <= want {
+		t.Errorf("unexpected height of radical: got:%v want more than %v", got, want)
+	}
+}
+
+func TestFillMath(t *testing.T) {
+	fnt, err := vg.MakeFont("Helvetica", 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sty := TextStyle{Font: fnt, Markup: true}
+
+	for _, test := range []struct {
+		txt     string
+		strings []string
+		fills   int
+	}{
+		{txt: "$x$ and $y$", strings: []string{"x", " and ", "y"}},
+		{txt: `$\alpha$`, fills: 1},
+		{txt: `$\sin x$`, strings: []string{"sin", "x"}},
+		{txt: `$\unknown$`, strings: []string{`\unknown`}},
+		{txt: `\$5`, strings: []string{"$5"}},
+	} {
+		var rec recorder.Canvas
+		c := NewCanvas(&rec, 100, 100)
+		c.FillText(sty, vg.Point{X: 10, Y: 10}, test.txt)
+		var strs []string
+		var fills int
+		for _, a := range rec.Actions {
+			switch a := a.(type) {
+			case *recorder.FillString:
+				strs = append(strs, a.String)
+			case *recorder.Fill:
+				fills++
+			}
+		}
+		if !equalStrings(strs, test.strings) {
+			t.Errorf("unexpected strings for %q: got:%q want:%q", test.txt, strs, test.strings)
+		}
+		if fills != test.fills {
+			t.Errorf("unexpected number of fills for %q: got:%d want:%d", test.txt, fills, test.fills)
+		}
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,338 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vg
+
+import (
+	"image/color"
+	"math"
+)
+
+// GradientKind is the kind of a Gradient.
+type GradientKind int
+
+const (
+	// NoGradient draws nothing. It is the kind
+	// of the zero Gradient.
+	NoGradient GradientKind = iota
+
+	// LinearGradient varies the color along the
+	// line from Start to End, keeping it constant
+	// along lines perpendicular to it.
+	LinearGradient
+
+	// RadialGradient varies the color with the
+	// distance from Start, reaching the end of
+	// the gradient on the circle through End.
+	RadialGradient
+)
+
+// GradientStop is a color at a position along a Gradient.
+type GradientStop struct {
+	// Offset is the position of the stop, from
+	// 0 at the start of the gradient to 1 at its
+	// end.
+	Offset float64
+
+	// Color is the color at the stop. If Color
+	// is nil black is used.
+	Color color.Color
+}
+
+// Gradient is a smooth transition between colors used to fill an
+// area. Colors are interpolated between neighboring stops. Before the
+// first stop the color of the first stop is used, and after the last
+// stop the color of the last stop is used. If Start and End are the
+// same point the area is filled with the color of the last stop.
+type Gradient struct {
+	// Kind is the kind of the gradient.
+	Kind GradientKind
+
+	// Start and End are the points at which offset
+	// 0 and offset 1 of the gradient are placed, in
+	// the coordinates of the canvas that is filled.
+	Start, End Point
+
+	// Stops are the colors of the gradient,
+	// sorted by increasing Offset.
+	Stops []GradientStop
+}
+
+// visible returns whether the gradient draws anything.
+func (g Gradient) visible() bool {
+	return g.Kind != NoGradient && len(g.Stops) != 0
+}
+
+// Offset returns the offset of the gradient at pt. The returned
+// offset is not limited to the range of the stops.
+func (g Gradient) Offset(pt Point) float64 {
+	d := g.End.Sub(g.Start)
+	switch g.Kind {
+	case LinearGradient:
+		l := d.Dot(d)
+		if l == 0 {
+			return math.Inf(1)
+		}
+		return float64(pt.Sub(g.Start).Dot(d) / l)
+	case RadialGradient:
+		r := math.Hypot(float64(d.X), float64(d.Y))
+		if r == 0 {
+			return math.Inf(1)
+		}
+		v := pt.Sub(g.Start)
+		return math.Hypot(float64(v.X), float64(v.Y)) / r
+	default:
+		panic("vg: unknown gradient kind")
+	}
+}
+
+// At returns the color of the gradient at offset t. At
+// returns nil if the gradient has no stops.
+func (g Gradient) At(t float64) color.Color {
+	if len(g.Stops) == 0 {
+		return nil
+	}
+	first, last := g.Stops[0], g.Stops[len(g.Stops)-1]
+	if t <= first.Offset {
+		return stopColor(first)
+	}
+	if t >= last.Offset {
+		return stopColor(last)
+	}
+	for i, s := range g.Stops[1:] {
+		if t > s.Offset {
+			continue
+		}
+		prev := g.Stops[i]
+		f := (t - prev.Offset) / (s.Offset - prev.Offset)
+		c0 := color.NRGBA64Model.Convert(stopColor(prev)).(color.NRGBA64)
+		c1 := color.NRGBA64Model.Convert(stopColor(s)).(color.NRGBA64)
+		lerp := func(a, b uint16) uint16 {
+			return uint16(math.Round(float64(a) + f*(float64(b)-float64(a))))
+		}
+		return color.NRGBA64{
+			R: lerp(c0.R, c1.R),
+			G: lerp(c0.G, c1.G),
+			B: lerp(c0.B, c1.B),
+			A: lerp(c0.A, c1.A),
+		}
+	}
+	return stopColor(last)
+}
+
+// stopColor returns the color of s, using
+// black if the color is nil.
+func stopColor(s GradientStop) color.Color {
+	if s.Color == nil {
+		return color.Black
+	}
+	return s.Color
+}
+
+// GradientFiller is implemented by canvases that can fill paths with
+// gradients natively. Canvases that do not implement it can be filled
+// with gradients using DrawGradient.
+type GradientFiller interface {
+	// FillGradient fills the path with the
+	// gradient as Fill fills it with a color,
+	// leaving the current state of the canvas
+	// unchanged.
+	FillGradient(Path, Gradient)
+}
+
+// DrawGradient fills the path p on the canvas c with the gradient g
+// by filling narrow bands of the path with flat colors. Curves in the
+// path are approximated by straight lines. Bands of opaque linear
+// gradients and of all radial gradients are drawn over each other to
+// avoid seams between them, so translucent colors in radial gradients
+// are not reproduced faithfully. The state of the canvas is unchanged.
+func DrawGradient(c Canvas, p Path, g Gradient) {
+	if !g.visible() {
+		return
+	}
+	rings := flattenPath(p)
+	if len(rings) == 0 {
+		return
+	}
+	c.Push()
+	defer c.Pop()
+	if g.Start == g.End {
+		c.SetColor(g.At(math.Inf(1)))
+		c.Fill(ringsPath(rings))
+		return
+	}
+	switch g.Kind {
+	case LinearGradient:
+		gradientStrips(c, rings, g)
+	case RadialGradient:
+		gradientDiscs(c, rings, g)
+	default:
+		panic("vg: unknown gradient kind")
+	}
+}
+
+// gradientBands returns the number of bands of a fallback
+// gradient fill spanning the given length.
+func gradientBands(l Length) int {
+	// bandWidth is the width of bands and maxBands
+	// is the largest number of bands that is drawn.
+	const (
+		bandWidth = Length(0.5)
+		maxBands  = 256
+	)
+	n := int(math.Ceil(float64(l / bandWidth)))
+	if n < 1 {
+		return 1
+	}
+	if n > maxBands {
+		return maxBands
+	}
+	return n
+}
+
+// gradientStrips fills the rings with strips of the linear
+// gradient g, merging neighboring strips of the same color.
+// Strips of opaque gradients extend to the end of the rings
+// and are covered by the following strips.
+func gradientStrips(c Canvas, rings [][]Point, g Gradient) {
+	opaque := true
+	for _, s := range g.Stops {
+		if _, _, _, a := stopColor(s).RGBA(); a != math.MaxUint16 {
+			opaque = false
+			break
+		}
+	}
+
+	d := g.End.Sub(g.Start)
+	l := Length(math.Hypot(float64(d.X), float64(d.Y)))
+	norm := d.Scale(1 / l)
+
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, r := range rings {
+		for _, p := range r {
+			t := g.Offset(p)
+			min = math.Min(min, t)
+			max = math.Max(max, t)
+		}
+	}
+	n := gradientBands(Length(max-min) * l)
+	step := (max - min) / float64(n)
+
+	var (
+		from = 0
+		clr  = g.At(min + step/2)
+	)
+	for i := 1; i <= n; i++ {
+		var next color.Color
+		if i < n {
+			next = g.At(min + (float64(i)+0.5)*step)
+			if sameColor(next, clr) {
+				continue
+			}
+		}
+		strip := rings
+		if from > 0 {
+			off := g.Start.Dot(norm) + Length(min+float64(from)*step)*l
+			strip = clipRings(strip, norm, off)
+		}
+		if i < n && !opaque {
+			off := g.Start.Dot(norm) + Length(min+float64(i)*step)*l
+			strip = clipRings(strip, norm.Scale(-1), -off)
+		}
+		if len(strip) != 0 {
+			c.SetColor(clr)
+			c.Fill(ringsPath(strip))
+		}
+		from, clr = i, next
+	}
+}
+
+// gradientDiscs fills the rings with discs of the radial
+// gradient g from the outside in, skipping discs of the
+// same color as the disc that contains them.
+func gradientDiscs(c Canvas, rings [][]Point, g Gradient) {
+	// sides is the number of sides of the
+	// polygons approximating discs.
+	const sides = 64
+
+	d := g.End.Sub(g.Start)
+	r := Length(math.Hypot(float64(d.X), float64(d.Y)))
+
+	var max float64
+	for _, ring := range rings {
+		for _, p := range ring {
+			max = math.Max(max, g.Offset(p))
+		}
+	}
+	n := gradientBands(Length(max) * r)
+	step := max / float64(n)
+
+	clr := g.At(max - step/2)
+	c.SetColor(clr)
+	c.Fill(ringsPath(rings))
+	for i := n - 1; i > 0; i-- {
+		next := g.At((float64(i) - 0.5) * step)
+		if sameColor(next, clr) {
+			continue
+		}
+		clr = next
+		// The disc is the polygon circumscribing
+		// the circle of radius i*step*r.
+		rad := Length(float64(i)*step) * r
+		disc := rings
+		for j := 0; j < sides && len(disc) != 0; j++ {
+			sin, cos := math.Sincos(2 * math.Pi * float64(j) / sides)
+			norm := Point{X: Length(-cos), Y: Length(-sin)}
+			disc = clipRings(disc, norm, g.Start.Dot(norm)-rad)
+		}
+		if len(disc) != 0 {
+			c.SetColor(clr)
+			c.Fill(ringsPath(disc))
+		}
+	}
+}
+
+// sameColor returns whether a and b are the same color.
+func sameColor(a, b color.Color) bool {
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+	return ar == br && ag == bg && ab == bb && aa == ba
+}
+
+// clipRings returns the parts of the rings in the half-plane
+// of points p with p·norm >= off.
+func clipRings(rings [][]Point, norm Point, off Length) [][]Point {
+	var clipped [][]Point
+	for _, r := range rings {
+		var out []Point
+		for i, a := range r {
+			b := r[(i+1)%len(r)]
+			da, db := a.Dot(norm)-off, b.Dot(norm)-off
+			if da >= 0 {
+				out = append(out, a)
+			}
+			if (da >= 0) != (db >= 0) {
+				t := da / (da - db)
+				out = append(out, a.Add(b.Sub(a).Scale(t)))
+			}
+		}
+		if len(out) > 2 {
+			clipped = append(clipped, out)
+		}
+	}
+	return clipped
+}
+
+// ringsPath returns the path of the closed rings.
+func ringsPath(rings [][]Point) Path {
+	var p Path
+	for _, r := range rings {
+		p.Move(r[0])
+		for _, pt := range r[1:] {
+			p.Line(pt)
+		}
+		p.Close()
+	}
+	return p
+}
@@ -0,0 +1,109 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vg_test
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/recorder"
+)
+
+func TestGradientAt(t *testing.T) {
+	g := vg.Gradient{
+		Kind:  vg.LinearGradient,
+		Start: vg.Point{X: 0, Y: 0},
+		End:   vg.Point{X: 10, Y: 0},
+		Stops: []vg.GradientStop{
+			{Offset: 0.25, Color: color.NRGBA{R: 255, A: 255}},
+			{Offset: 0.75, Color: color.NRGBA{B: 255, A: 255}},
+			{Offset: 0.75},
+		},
+	}
+	for _, test := range []struct {
+		pt   vg.Point
+		want color.NRGBA
+	}{
+		{pt: vg.Point{X: -5, Y: 3}, want: color.NRGBA{R: 255, A: 255}},
+		{pt: vg.Point{X: 2.5, Y: 0}, want: color.NRGBA{R: 255, A: 255}},
+		{pt: vg.Point{X: 5, Y: -7}, want: color.NRGBA{R: 128, B: 128, A: 255}},
+		{pt: vg.Point{X: 7.4, Y: 0}, want: color.NRGBA{R: 5, B: 250, A: 255}},
+		{pt: vg.Point{X: 7.6, Y: 0}, want: color.NRGBA{A: 255}},
+		{pt: vg.Point{X: 20, Y: 1}, want: color.NRGBA{A: 255}},
+	} {
+		got := color.NRGBAModel.Convert(g.At(g.Offset(test.pt))).(color.NRGBA)
+		if got != test.want {
+			t.Errorf("unexpected color at %+v: got:%v want:%v", test.pt, got, test.want)
+		}
+	}
+
+	g.Kind = vg.RadialGradient
+	if got := g.Offset(vg.Point{X: 3, Y: 4}); got != 0.5 {
+		t.Errorf("unexpected radial offset: got:%v want:0.5", got)
+	}
+}
+
+func TestDrawGradient(t *testing.T) {
+	var p vg.Path
+	p.Move(vg.Point{X: 0, Y: 0})
+	p.Line(vg.Point{X: 10, Y: 0})
+	p.Line(vg.Point{X: 10, Y: 10})
+	p.Line(vg.Point{X: 0, Y: 10})
+	p.Close()
+
+	black := color.NRGBA{A: 255}
+	white := color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+	for _, kind := range []vg.GradientKind{vg.LinearGradient, vg.RadialGradient} {
+		g := vg.Gradient{
+			Kind:  kind,
+			Start: vg.Point{X: 0, Y: 0},
+			End:   vg.Point{X: 10, Y: 0},
+			Stops: []vg.GradientStop{
+				{Offset: 0.2, Color: black},
+				{Offset: 0.8, Color: white},
+			},
+		}
+		var c recorder.Canvas
+		vg.DrawGradient(&c, p, g)
+
+		var (
+			clr    color.Color
+			colors []color.Color
+		)
+		for _, a := range c.Actions {
+			switch a := a.(type) {
+			case *recorder.SetColor:
+				clr = a.Color
+			case *recorder.Fill:
+				colors = append(colors, clr)
+				for _, comp := range a.Path {
+					if comp.Type == vg.CloseComp {
+						continue
+					}
+					off := g.Offset(comp.Pos)
+					if comp.Pos.X < -1e-9 || comp.Pos.X > 10+1e-9 || comp.Pos.Y < -1e-9 || comp.Pos.Y > 10+1e-9 || math.IsNaN(off) {
+						t.Errorf("unexpected point in band of gradient kind %d: %+v", kind, comp.Pos)
+					}
+				}
+			}
+		}
+		// Bands of the same color are merged, so the
+		// flat parts of the gradient are filled once.
+		if n := len(colors); n < 3 || n > 41 {
+			t.Errorf("unexpected number of bands for gradient kind %d: got:%d", kind, n)
+			continue
+		}
+		first, last := colors[0], colors[len(colors)-1]
+		if kind == vg.RadialGradient {
+			// Radial gradients are drawn from the outside in.
+			first, last = last, first
+		}
+		if first != color.Color(black) || last != color.Color(white) {
+			t.Errorf("unexpected colors of gradient kind %d: got:%v...%v want:%v...%v", kind, first, last, black, white)
+		}
+	}
+}
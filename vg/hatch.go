@@ -0,0 +1,276 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vg
+
+import (
+	"image/color"
+	"math"
+	"sort"
+)
+
+// HatchPattern is the pattern of a Hatch.
+type HatchPattern int
+
+const (
+	// NoHatch draws nothing. It is the pattern
+	// of the zero Hatch.
+	NoHatch HatchPattern = iota
+
+	// DiagonalHatch draws parallel lines rising
+	// from left to right at 45°.
+	DiagonalHatch
+
+	// CrossHatch draws a grid of horizontal
+	// and vertical lines.
+	CrossHatch
+
+	// DotHatch draws a square grid of dots.
+	DotHatch
+
+	// HorizontalHatch draws horizontal lines.
+	HorizontalHatch
+
+	// VerticalHatch draws vertical lines.
+	VerticalHatch
+)
+
+// Hatch is a pattern of lines or dots used to fill an area, so that
+// areas can be told apart without color. Patterns are aligned to the
+// origin of the canvas so that neighboring areas with the same Hatch
+// join seamlessly.
+type Hatch struct {
+	// Pattern is the pattern of the hatch.
+	Pattern HatchPattern
+
+	// Color is the color of the lines or dots.
+	// If Color is nil black is used.
+	Color color.Color
+
+	// Spacing is the distance between
+	// neighboring lines or dots.
+	Spacing Length
+
+	// Width is the width of the lines
+	// or the diameter of the dots.
+	Width Length
+}
+
+// visible returns whether the hatch draws anything.
+func (h Hatch) visible() bool {
+	return h.Pattern != NoHatch && h.Spacing > 0 && h.Width > 0
+}
+
+// HatchFiller is implemented by canvases that fill paths with hatch
+// patterns themselves, such as with the native patterns of their
+// format. Canvases that do not implement it can be hatched using
+// DrawHatch.
+type HatchFiller interface {
+	// FillHatch fills the path with the hatch
+	// pattern using the even-odd rule, leaving
+	// the current state of the canvas unchanged.
+	FillHatch(Path, Hatch)
+}
+
+// DrawHatch fills the path p on the canvas c with the hatch h by
+// stroking lines or filling dots that are clipped to the path using
+// the even-odd rule. Curves in the path are approximated by straight
+// lines and dots are drawn if their centers are inside the path. The
+// state of the canvas is unchanged.
+func DrawHatch(c Canvas, p Path, h Hatch) {
+	if !h.visible() {
+		return
+	}
+	rings := flattenPath(p)
+	if len(rings) == 0 {
+		return
+	}
+	c.Push()
+	defer c.Pop()
+	c.SetColor(h.Color)
+	c.SetLineWidth(h.Width)
+	c.SetLineDash(nil, 0)
+	switch h.Pattern {
+	case DiagonalHatch:
+		hatchLines(c, rings, math.Pi/4, h.Spacing)
+	case CrossHatch:
+		hatchLines(c, rings, 0, h.Spacing)
+		hatchLines(c, rings, math.Pi/2, h.Spacing)
+	case DotHatch:
+		hatchDots(c, rings, h.Spacing, h.Width/2)
+	case HorizontalHatch:
+		hatchLines(c, rings, 0, h.Spacing)
+	case VerticalHatch:
+		hatchLines(c, rings, math.Pi/2, h.Spacing)
+	default:
+		panic("vg: unknown hatch pattern")
+	}
+}
+
+// hatchLines strokes the parts inside the rings of the lines at the
+// given angle from the X axis that are placed at odd multiples of half
+// the spacing from the origin.
+func hatchLines(c Canvas, rings [][]Point, angle float64, spacing Length) {
+	sin, cos := math.Sincos(angle)
+	dir := Point{X: Length(cos), Y: Length(sin)}
+	norm := Point{X: Length(-sin), Y: Length(cos)}
+
+	min, max := Length(math.Inf(1)), Length(math.Inf(-1))
+	for _, r := range rings {
+		for _, p := range r {
+			d := p.Dot(norm)
+			min = Length(math.Min(float64(min), float64(d)))
+			max = Length(math.Max(float64(max), float64(d)))
+		}
+	}
+
+	var path Path
+	var cross []float64
+	for k := math.Ceil(float64(min/spacing) - 0.5); float64(max/spacing)-0.5 >= k; k++ {
+		off := Length(k+0.5) * spacing
+		cross = cross[:0]
+		for _, r := range rings {
+			for i, a := range r {
+				b := r[(i+1)%len(r)]
+				da, db := a.Dot(norm)-off, b.Dot(norm)-off
+				if (da >= 0) == (db >= 0) {
+					continue
+				}
+				t := da / (da - db)
+				x := a.Add(b.Sub(a).Scale(t))
+				cross = append(cross, float64(x.Dot(dir)))
+			}
+		}
+		sort.Float64s(cross)
+		base := norm.Scale(off)
+		for i := 0; i+1 < len(cross); i += 2 {
+			path.Move(base.Add(dir.Scale(Length(cross[i]))))
+			path.Line(base.Add(dir.Scale(Length(cross[i+1]))))
+		}
+	}
+	if len(path) != 0 {
+		c.Stroke(path)
+	}
+}
+
+// hatchDots fills dots of radius r inside the rings at the points of
+// a square grid with the given spacing, offset from the origin by half
+// the spacing.
+func hatchDots(c Canvas, rings [][]Point, spacing, r Length) {
+	min := Point{X: Length(math.Inf(1)), Y: Length(math.Inf(1))}
+	max := Point{X: Length(math.Inf(-1)), Y: Length(math.Inf(-1))}
+	for _, ring := range rings {
+		for _, p := range ring {
+			min.X = Length(math.Min(float64(min.X), float64(p.X)))
+			min.Y = Length(math.Min(float64(min.Y), float64(p.Y)))
+			max.X = Length(math.Max(float64(max.X), float64(p.X)))
+			max.Y = Length(math.Max(float64(max.Y), float64(p.Y)))
+		}
+	}
+
+	var path Path
+	for j := math.Ceil(float64(min.Y/spacing) - 0.5); float64(max.Y/spacing)-0.5 >= j; j++ {
+		for i := math.Ceil(float64(min.X/spacing) - 0.5); float64(max.X/spacing)-0.5 >= i; i++ {
+			pt := Point{X: Length(i+0.5) * spacing, Y: Length(j+0.5) * spacing}
+			if !insideRings(pt, rings) {
+				continue
+			}
+			path.Move(Point{X: pt.X + r, Y: pt.Y})
+			path.Arc(pt, r, 0, 2*math.Pi)
+			path.Close()
+		}
+	}
+	if len(path) != 0 {
+		c.Fill(path)
+	}
+}
+
+// insideRings returns whether pt is inside the
+// rings using the even-odd rule.
+func insideRings(pt Point, rings [][]Point) bool {
+	var in bool
+	for _, r := range rings {
+		for i, a := range r {
+			b := r[(i+1)%len(r)]
+			if (a.Y > pt.Y) != (b.Y > pt.Y) && pt.X < a.X+(pt.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y) {
+				in = !in
+			}
+		}
+	}
+	return in
+}
+
+// flattenPath returns the closed rings of points
+// approximating the subpaths of p.
+func flattenPath(p Path) [][]Point {
+	// curveSteps is the number of straight lines
+	// approximating each curve, and arcStep is the
+	// largest angle approximated by a straight line.
+	const (
+		curveSteps = 16
+		arcStep    = math.Pi / 36
+	)
+
+	var rings [][]Point
+	var ring []Point
+	end := func() {
+		if len(ring) > 2 {
+			rings = append(rings, ring)
+		}
+		ring = nil
+	}
+	for _, comp := range p {
+		switch comp.Type {
+		case MoveComp:
+			end()
+			ring = []Point{comp.Pos}
+		case LineComp:
+			ring = append(ring, comp.Pos)
+		case ArcComp:
+			n := int(math.Ceil(math.Abs(comp.Angle) / arcStep))
+			if n < 1 {
+				n = 1
+			}
+			for i := 0; i <= n; i++ {
+				sin, cos := math.Sincos(comp.Start + comp.Angle*float64(i)/float64(n))
+				ring = append(ring, Point{
+					X: comp.Pos.X + comp.Radius*Length(cos),
+					Y: comp.Pos.Y + comp.Radius*Length(sin),
+				})
+			}
+		case CurveComp:
+			if len(ring) == 0 {
+				ring = append(ring, comp.Pos)
+				continue
+			}
+			start := ring[len(ring)-1]
+			for i := 1; i <= curveSteps; i++ {
+				ring = append(ring, bezier(start, comp.Control, comp.Pos, float64(i)/curveSteps))
+			}
+		case CloseComp:
+			if len(ring) != 0 {
+				start := ring[0]
+				end()
+				ring = []Point{start}
+			}
+		default:
+			panic("vg: unknown path component")
+		}
+	}
+	end()
+	return rings
+}
+
+// bezier returns the point at t of the quadratic or cubic
+// Bézier curve from start to end with the given controls.
+func bezier(start Point, ctrl []Point, end Point, t float64) Point {
+	pts := append(append([]Point{start}, ctrl...), end)
+	for len(pts) > 1 {
+		for i := range pts[:len(pts)-1] {
+			pts[i] = pts[i].Add(pts[i+1].Sub(pts[i]).Scale(Length(t)))
+		}
+		pts = pts[:len(pts)-1]
+	}
+	return pts[0]
+}
@@ -0,0 +1,66 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vg_test
+
+import (
+	"testing"
+
+	"github.com/gshk/plot/vg"
+	"github.com/gshk/plot/vg/recorder"
+)
+
+func TestDrawHatch(t *testing.T) {
+	// An L-shaped region covering [0,10]x[0,4]
+	// and [0,4]x[4,10].
+	var p vg.Path
+	p.Move(vg.Point{X: 0, Y: 0})
+	p.Line(vg.Point{X: 10, Y: 0})
+	p.Line(vg.Point{X: 10, Y: 4})
+	p.Line(vg.Point{X: 4, Y: 4})
+	p.Line(vg.Point{X: 4, Y: 10})
+	p.Line(vg.Point{X: 0, Y: 10})
+	p.Close()
+
+	for _, test := range []struct {
+		pattern vg.HatchPattern
+		want    int
+	}{
+		{pattern: vg.NoHatch, want: 0},
+		{pattern: vg.HorizontalHatch, want: 5},
+		{pattern: vg.VerticalHatch, want: 5},
+		{pattern: vg.CrossHatch, want: 10},
+		{pattern: vg.DotHatch, want: 2*5 + 3*2},
+	} {
+		var c recorder.Canvas
+		vg.DrawHatch(&c, p, vg.Hatch{Pattern: test.pattern, Spacing: 2, Width: 0.5})
+
+		var got int
+		for _, a := range c.Actions {
+			var path vg.Path
+			switch a := a.(type) {
+			case *recorder.Stroke:
+				path = a.Path
+			case *recorder.Fill:
+				path = a.Path
+			default:
+				continue
+			}
+			for _, comp := range path {
+				switch comp.Type {
+				case vg.MoveComp:
+					got++
+				case vg.LineComp:
+					if comp.Pos.X < 0 || comp.Pos.X > 10 || comp.Pos.Y < 0 || comp.Pos.Y > 10 ||
+						(comp.Pos.X > 4 && comp.Pos.Y > 4) {
+						t.Errorf("hatch line of pattern %d outside path: %+v", test.pattern, comp.Pos)
+					}
+				}
+			}
+		}
+		if got != test.want {
+			t.Errorf("unexpected number of hatch elements for pattern %d: got:%d want:%d", test.pattern, got, test.want)
+		}
+	}
+}
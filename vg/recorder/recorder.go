@@ -168,6 +168,110 @@ func (a *SetLineDash) callerLocation() *callerLocation {
 	return &a.l
 }
 
+// SetLineCap corresponds to the vg.Canvas.SetLineCap method.
+type SetLineCap struct {
+	Cap vg.LineCap
+
+	l callerLocation
+}
+
+// SetLineCap implements the SetLineCap method of the vg.Canvas interface.
+func (c *Canvas) SetLineCap(lc vg.LineCap) {
+	c.append(&SetLineCap{Cap: lc})
+}
+
+// Call returns the method call that generated the action.
+func (a *SetLineCap) Call() string {
+	return fmt.Sprintf("%sSetLineCap(%v)", a.l, a.Cap)
+}
+
+// ApplyTo applies the action to the given vg.Canvas.
+func (a *SetLineCap) ApplyTo(c vg.Canvas) {
+	c.SetLineCap(a.Cap)
+}
+
+func (a *SetLineCap) callerLocation() *callerLocation {
+	return &a.l
+}
+
+// SetLineJoin corresponds to the vg.Canvas.SetLineJoin method.
+type SetLineJoin struct {
+	Join vg.LineJoin
+
+	l callerLocation
+}
+
+// SetLineJoin implements the SetLineJoin method of the vg.Canvas interface.
+func (c *Canvas) SetLineJoin(join vg.LineJoin) {
+	c.append(&SetLineJoin{Join: join})
+}
+
+// Call returns the method call that generated the action.
+func (a *SetLineJoin) Call() string {
+	return fmt.Sprintf("%sSetLineJoin(%v)", a.l, a.Join)
+}
+
+// ApplyTo applies the action to the given vg.Canvas.
+func (a *SetLineJoin) ApplyTo(c vg.Canvas) {
+	c.SetLineJoin(a.Join)
+}
+
+func (a *SetLineJoin) callerLocation() *callerLocation {
+	return &a.l
+}
+
+// SetMiterLimit corresponds to the vg.Canvas.SetMiterLimit method.
+type SetMiterLimit struct {
+	Limit float64
+
+	l callerLocation
+}
+
+// SetMiterLimit implements the SetMiterLimit method of the vg.Canvas interface.
+func (c *Canvas) SetMiterLimit(limit float64) {
+	c.append(&SetMiterLimit{Limit: limit})
+}
+
+// Call returns the method call that generated the action.
+func (a *SetMiterLimit) Call() string {
+	return fmt.Sprintf("%sSetMiterLimit(%v)", a.l, a.Limit)
+}
+
+// ApplyTo applies the action to the given vg.Canvas.
+func (a *SetMiterLimit) ApplyTo(c vg.Canvas) {
+	c.SetMiterLimit(a.Limit)
+}
+
+func (a *SetMiterLimit) callerLocation() *callerLocation {
+	return &a.l
+}
+
+// SetFillRule corresponds to the vg.Canvas.SetFillRule method.
+type SetFillRule struct {
+	Rule vg.FillRule
+
+	l callerLocation
+}
+
+// SetFillRule implements the SetFillRule method of the vg.Canvas interface.
+func (c *Canvas) SetFillRule(rule vg.FillRule) {
+	c.append(&SetFillRule{Rule: rule})
+}
+
+// Call returns the method call that generated the action.
+func (a *SetFillRule) Call() string {
+	return fmt.Sprintf("%sSetFillRule(%v)", a.l, a.Rule)
+}
+
+// ApplyTo applies the action to the given vg.Canvas.
+func (a *SetFillRule) ApplyTo(c vg.Canvas) {
+	c.SetFillRule(a.Rule)
+}
+
+func (a *SetFillRule) callerLocation() *callerLocation {
+	return &a.l
+}
+
 // SetColor corresponds to the vg.Canvas.SetColor method.
 type SetColor struct {
 	Color color.Color
@@ -272,6 +376,32 @@ func (a *Scale) callerLocation() *callerLocation {
 	return &a.l
 }
 
+// Clip corresponds to the vg.Canvas.Clip method.
+type Clip struct {
+	Path vg.Path
+
+	l callerLocation
+}
+
+// Clip implements the Clip method of the vg.Canvas interface.
+func (c *Canvas) Clip(path vg.Path) {
+	c.append(&Clip{Path: append(vg.Path(nil), path...)})
+}
+
+// Call returns the method call that generated the action.
+func (a *Clip) Call() string {
+	return fmt.Sprintf("%sClip(%#v)", a.l, a.Path)
+}
+
+// ApplyTo applies the action to the given vg.Canvas.
+func (a *Clip) ApplyTo(c vg.Canvas) {
+	c.Clip(a.Path)
+}
+
+func (a *Clip) callerLocation() *callerLocation {
+	return &a.l
+}
+
 // Push corresponds to the vg.Canvas.Push method.
 type Push struct {
 	l callerLocation
@@ -27,6 +27,10 @@ func TestRecorder(t *testing.T) {
 	rec.KeepCaller = false
 	rec.SetLineWidth(100)
 	rec.SetLineDash([]vg.Length{2, 5}, 6)
+	rec.SetLineCap(vg.RoundCap)
+	rec.SetLineJoin(vg.BevelJoin)
+	rec.SetMiterLimit(4)
+	rec.SetFillRule(vg.EvenOdd)
 	rec.SetColor(color.RGBA{R: 0x65, G: 0x23, B: 0xf2})
 	rec.Fill(vg.Path{{Type: vg.MoveComp, Pos: vg.Point{X: 3, Y: 4}}, {Type: vg.LineComp, Pos: vg.Point{X: 2, Y: 3}}, {Type: vg.CloseComp}})
 	rec.DrawImage(vg.Rectangle{vg.Point{0, 0}, vg.Point{10, 10}}, img)
@@ -73,6 +77,10 @@ var want = []string{
 	`github.com/gshk/plot/vg/recorder/recorder_test.go:26 Translate(3, 4)`,
 	`SetLineWidth(100)`,
 	`SetLineDash([]vg.Length{2, 5}, 6)`,
+	`SetLineCap(1)`,
+	`SetLineJoin(3)`,
+	`SetMiterLimit(4)`,
+	`SetFillRule(1)`,
 	`SetColor(color.RGBA{R:0x65, G:0x23, B:0xf2, A:0x0})`,
 	`Fill(vg.Path{vg.PathComp{Type:0, Pos:vg.Point{X:3, Y:4}, Control:[]vg.Point(nil), Radius:0, Start:0, Angle:0}, vg.PathComp{Type:1, Pos:vg.Point{X:2, Y:3}, Control:[]vg.Point(nil), Radius:0, Start:0, Angle:0}, vg.PathComp{Type:4, Pos:vg.Point{X:0, Y:0}, Control:[]vg.Point(nil), Radius:0, Start:0, Angle:0}})`,
 	`DrawImage(vg.Rectangle{Min:vg.Point{X:0, Y:0}, Max:vg.Point{X:10, Y:10}}, {image.Rectangle{Min:image.Point{X:0, Y:0}, Max:image.Point{X:20, Y:20}}, IMAGE:iVBORw0KGgoAAAANSUhEUgAAABQAAAAUCAAAAACo4kLRAAAAFElEQVR4nGJiwAJGBQeVICAAAP//JBgAKeMueQ8AAAAASUVORK5CYII=})`,
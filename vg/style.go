@@ -0,0 +1,68 @@
+// Copyright ©2019 The Gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vg
+
+// FillRule is the rule used to decide which
+// points are inside a path when it is filled
+// or used for clipping.
+type FillRule int
+
+const (
+	// NonZero fills points that are encircled
+	// by the path a non-zero number of times,
+	// counting each subpath with its direction.
+	// Inner subpaths that wind in the opposite
+	// direction to the outer subpath are holes.
+	NonZero FillRule = iota
+
+	// EvenOdd fills points that are encircled
+	// by the path an odd number of times, so
+	// all inner subpaths are holes.
+	EvenOdd
+)
+
+// LineCap is the shape of the ends of stroked lines.
+type LineCap int
+
+const (
+	// ButtCap ends lines squarely at their end points.
+	ButtCap LineCap = iota
+
+	// RoundCap ends lines with half circles
+	// centered on their end points.
+	RoundCap
+
+	// SquareCap ends lines squarely, extended past
+	// their end points by half the line width.
+	SquareCap
+)
+
+// LineJoin is the shape of the corners of stroked lines.
+type LineJoin int
+
+const (
+	// DefaultJoin is the initial join of a Canvas.
+	// It is the default join of the output format:
+	// a miter join with the default miter limit of
+	// the format in all backends except vgimg,
+	// which draws round joins.
+	DefaultJoin LineJoin = iota
+
+	// MiterJoin extends the outer edges of the
+	// lines until they meet. Joins with a miter
+	// longer than the miter limit are beveled.
+	MiterJoin
+
+	// RoundJoin rounds corners with circular arcs.
+	RoundJoin
+
+	// BevelJoin cuts corners off squarely.
+	BevelJoin
+)
+
+// DefaultMiterLimit is the initial miter limit of a Canvas.
+// Miter joins between lines meeting at angles of less than
+// about 11.5° are beveled.
+const DefaultMiterLimit = 10
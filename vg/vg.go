@@ -32,6 +32,33 @@ type Canvas interface {
 	// The initial dash pattern is a solid line.
 	SetLineDash(pattern []Length, offset Length)
 
+	// SetLineCap sets the shape of the ends of
+	// stroked lines and dashes.
+	//
+	// The initial line cap is ButtCap.
+	SetLineCap(LineCap)
+
+	// SetLineJoin sets the shape of the corners
+	// of stroked lines.
+	//
+	// The initial line join is DefaultJoin.
+	SetLineJoin(LineJoin)
+
+	// SetMiterLimit sets the limit of the ratio of
+	// the length of a miter join to the line width,
+	// beyond which the join is beveled. Limits less
+	// than 1 are treated as 1.
+	//
+	// The initial miter limit is DefaultMiterLimit.
+	SetMiterLimit(float64)
+
+	// SetFillRule sets the rule used to decide
+	// which points are inside of filled paths
+	// and of clipping paths.
+	//
+	// The initial fill rule is NonZero.
+	SetFillRule(FillRule)
+
 	// SetColor sets the current drawing color.
 	// Note that fill color and stroke color are
 	// the same, so if you want different fill
@@ -55,11 +82,20 @@ type Canvas interface {
 	// context.
 	Scale(x, y float64)
 
+	// Clip intersects the current clipping
+	// region with the interior of the given
+	// path, so that nothing outside of it is
+	// drawn until the corresponding call to
+	// Pop(). Initially nothing is clipped.
+	Clip(Path)
+
 	// Push saves the current line width, the
-	// current dash pattern, the current
-	// transforms, and the current color
-	// onto a stack so that the state can later
-	// be restored by calling Pop().
+	// current dash pattern, line cap, line join
+	// and miter limit, the current fill rule,
+	// the current transforms, the current color
+	// and the current clipping region onto a
+	// stack so that the state can later be
+	// restored by calling Pop().
 	Push()
 
 	// Pop restores the context saved by the
@@ -94,11 +130,36 @@ type CanvasWriterTo interface {
 	io.WriterTo
 }
 
+// FontFiller is implemented by canvases that draw strings in the
+// font of their document rather than in the font given to FillString.
+// Parts of marked up text in fonts other than the font of their text
+// style are drawn on a FontFiller with FillFont.
+type FontFiller interface {
+	// FillFont fills in text at the specified
+	// location, selecting the size and the
+	// variant of the given font.
+	FillFont(f Font, pt Point, text string)
+}
+
+// MathFiller is implemented by canvases that typeset TeX math
+// natively. Formulas in marked up text drawn on a MathFiller are
+// passed to FillMath instead of being laid out by the draw package.
+type MathFiller interface {
+	// FillMath fills the TeX math in src, without the
+	// enclosing dollar signs, in the given font with the
+	// left end of its baseline at pt.
+	FillMath(fnt Font, pt Point, src string)
+}
+
 // Initialize sets all of the canvas's values to their
 // initial values.
 func Initialize(c Canvas) {
 	c.SetLineWidth(Points(1))
 	c.SetLineDash([]Length{}, 0)
+	c.SetLineCap(ButtCap)
+	c.SetLineJoin(DefaultJoin)
+	c.SetMiterLimit(DefaultMiterLimit)
+	c.SetFillRule(NonZero)
 	c.SetColor(color.Black)
 }
 
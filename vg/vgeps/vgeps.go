@@ -33,6 +33,10 @@ type context struct {
 	width  vg.Length
 	dashes []vg.Length
 	offs   vg.Length
+	cap    vg.LineCap
+	join   vg.LineJoin
+	limit  float64
+	rule   vg.FillRule
 	font   string
 	fsize  vg.Length
 }
@@ -48,7 +52,7 @@ func New(w, h vg.Length) *Canvas {
 // NewTitle returns a new Canvas with the given title string.
 func NewTitle(w, h vg.Length, title string) *Canvas {
 	c := &Canvas{
-		stack: []context{{}},
+		stack: []context{{limit: vg.DefaultMiterLimit}},
 		w:     w,
 		h:     h,
 		buf:   new(bytes.Buffer),
@@ -103,6 +107,64 @@ func (e *Canvas) SetLineDash(dashes []vg.Length, o vg.Length) {
 	}
 }
 
+// SetLineCap implements the vg.Canvas.SetLineCap method.
+func (e *Canvas) SetLineCap(lc vg.LineCap) {
+	if e.context().cap != lc {
+		e.context().cap = lc
+		fmt.Fprintf(e.buf, "%d setlinecap\n", lineCap(lc))
+	}
+}
+
+// SetLineJoin implements the vg.Canvas.SetLineJoin method.
+func (e *Canvas) SetLineJoin(join vg.LineJoin) {
+	if e.context().join != join {
+		e.context().join = join
+		fmt.Fprintf(e.buf, "%d setlinejoin\n", lineJoin(join))
+	}
+}
+
+// SetMiterLimit implements the vg.Canvas.SetMiterLimit method.
+func (e *Canvas) SetMiterLimit(limit float64) {
+	limit = math.Max(limit, 1)
+	if e.context().limit != limit {
+		e.context().limit = limit
+		fmt.Fprintf(e.buf, "%.*g setmiterlimit\n", pr, limit)
+	}
+}
+
+// SetFillRule implements the vg.Canvas.SetFillRule method.
+func (e *Canvas) SetFillRule(rule vg.FillRule) {
+	e.context().rule = rule
+}
+
+// lineCap returns the PostScript code of the line cap.
+func lineCap(lc vg.LineCap) int {
+	switch lc {
+	case vg.ButtCap:
+		return 0
+	case vg.RoundCap:
+		return 1
+	case vg.SquareCap:
+		return 2
+	default:
+		panic(fmt.Sprintf("vgeps: unknown line cap %d", lc))
+	}
+}
+
+// lineJoin returns the PostScript code of the line join.
+func lineJoin(join vg.LineJoin) int {
+	switch join {
+	case vg.DefaultJoin, vg.MiterJoin:
+		return 0
+	case vg.RoundJoin:
+		return 1
+	case vg.BevelJoin:
+		return 2
+	default:
+		panic(fmt.Sprintf("vgeps: unknown line join %d", join))
+	}
+}
+
 func (e *Canvas) SetColor(c color.Color) {
 	if c == nil {
 		c = color.Black
@@ -139,6 +201,16 @@ func (e *Canvas) Pop() {
 	e.buf.WriteString("grestore\n")
 }
 
+// Clip implements the vg.Canvas.Clip method.
+func (e *Canvas) Clip(path vg.Path) {
+	e.trace(path)
+	if e.context().rule == vg.EvenOdd {
+		e.buf.WriteString("eoclip newpath\n")
+		return
+	}
+	e.buf.WriteString("clip newpath\n")
+}
+
 func (e *Canvas) Stroke(path vg.Path) {
 	if e.context().width <= 0 {
 		return
@@ -149,6 +221,10 @@ func (e *Canvas) Stroke(path vg.Path) {
 
 func (e *Canvas) Fill(path vg.Path) {
 	e.trace(path)
+	if e.context().rule == vg.EvenOdd {
+		e.buf.WriteString("eofill\n")
+		return
+	}
 	e.buf.WriteString("fill\n")
 }
 
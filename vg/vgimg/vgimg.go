@@ -15,6 +15,7 @@ import (
 	"image/jpeg"
 	"image/png"
 	"io"
+	"math"
 
 	"github.com/fogleman/gg"
 	"golang.org/x/image/tiff"
@@ -28,19 +29,36 @@ type Canvas struct {
 	ctx   *gg.Context
 	img   draw.Image
 	w, h  vg.Length
-	color []color.Color
+	stack []context
 
 	// dpi is the number of dots per inch for this canvas.
 	dpi int
 
-	// width is the current line width.
-	width vg.Length
-
 	// backgroundColor is the background color, set by
 	// UseBackgroundColor.
 	backgroundColor color.Color
 }
 
+// context is the state of the canvas that is saved
+// by Push and that is not kept by the gg context.
+type context struct {
+	color color.Color
+
+	// width is the current line width.
+	width vg.Length
+
+	// dashed is whether lines are dashed.
+	dashed bool
+
+	join  vg.LineJoin
+	limit float64
+	rule  vg.FillRule
+
+	// clip is the clipping mask,
+	// nil if nothing is clipped.
+	clip *image.Alpha
+}
+
 const (
 	// DefaultDPI is the default dot resolution for image
 	// drawing in dots per inch.
@@ -102,7 +120,7 @@ func NewWith(o ...option) *Canvas {
 		c.ctx.InvertY()
 	}
 	draw.Draw(c.img, c.img.Bounds(), &image.Uniform{c.backgroundColor}, image.ZP, draw.Src)
-	c.color = []color.Color{color.Black}
+	c.stack = []context{{color: color.Black}}
 	vg.Initialize(c)
 	return c
 }
@@ -185,8 +203,12 @@ func (c *Canvas) Size() (w, h vg.Length) {
 	return c.w, c.h
 }
 
+func (c *Canvas) context() *context {
+	return &c.stack[len(c.stack)-1]
+}
+
 func (c *Canvas) SetLineWidth(w vg.Length) {
-	c.width = w
+	c.context().width = w
 	c.ctx.SetLineWidth(w.Dots(c.DPI()))
 }
 
@@ -197,6 +219,55 @@ func (c *Canvas) SetLineDash(ds []vg.Length, offs vg.Length) {
 	}
 	c.ctx.SetDashOffset(offs.Dots(c.DPI()))
 	c.ctx.SetDash(dashes...)
+	c.context().dashed = len(ds) != 0
+}
+
+// SetLineCap implements the vg.Canvas.SetLineCap method.
+func (c *Canvas) SetLineCap(lc vg.LineCap) {
+	switch lc {
+	case vg.ButtCap:
+		c.ctx.SetLineCapButt()
+	case vg.RoundCap:
+		c.ctx.SetLineCapRound()
+	case vg.SquareCap:
+		c.ctx.SetLineCapSquare()
+	default:
+		panic(fmt.Sprintf("vgimg: unknown line cap %d", lc))
+	}
+}
+
+// SetLineJoin implements the vg.Canvas.SetLineJoin method.
+// The default join is the round join of the gg context.
+// The gg context has no miter joins, so the tips of miter
+// joins are filled by Stroke after beveling the joins.
+func (c *Canvas) SetLineJoin(lj vg.LineJoin) {
+	switch lj {
+	case vg.MiterJoin, vg.BevelJoin:
+		c.ctx.SetLineJoinBevel()
+	case vg.DefaultJoin, vg.RoundJoin:
+		c.ctx.SetLineJoinRound()
+	default:
+		panic(fmt.Sprintf("vgimg: unknown line join %d", lj))
+	}
+	c.context().join = lj
+}
+
+// SetMiterLimit implements the vg.Canvas.SetMiterLimit method.
+func (c *Canvas) SetMiterLimit(limit float64) {
+	c.context().limit = math.Max(limit, 1)
+}
+
+// SetFillRule implements the vg.Canvas.SetFillRule method.
+func (c *Canvas) SetFillRule(rule vg.FillRule) {
+	switch rule {
+	case vg.NonZero:
+		c.ctx.SetFillRuleWinding()
+	case vg.EvenOdd:
+		c.ctx.SetFillRuleEvenOdd()
+	default:
+		panic(fmt.Sprintf("vgimg: unknown fill rule %d", rule))
+	}
+	c.context().rule = rule
 }
 
 func (c *Canvas) SetColor(clr color.Color) {
@@ -204,7 +275,7 @@ func (c *Canvas) SetColor(clr color.Color) {
 		clr = color.Black
 	}
 	c.ctx.SetColor(clr)
-	c.color[len(c.color)-1] = clr
+	c.context().color = clr
 }
 
 func (c *Canvas) Rotate(t float64) {
@@ -220,21 +291,233 @@ func (c *Canvas) Scale(x, y float64) {
 }
 
 func (c *Canvas) Push() {
-	c.color = append(c.color, c.color[len(c.color)-1])
+	c.stack = append(c.stack, *c.context())
 	c.ctx.Push()
 }
 
 func (c *Canvas) Pop() {
-	c.color = c.color[:len(c.color)-1]
+	mask := c.context().clip
+	c.stack = c.stack[:len(c.stack)-1]
 	c.ctx.Pop()
+	// The gg context keeps its clipping mask
+	// on Pop, so it is restored here.
+	if prev := c.context().clip; prev != mask {
+		if prev == nil {
+			c.ctx.ResetClip()
+		} else {
+			c.ctx.SetMask(prev)
+		}
+	}
+}
+
+// Clip implements the vg.Canvas.Clip method.
+func (c *Canvas) Clip(p vg.Path) {
+	// The mask is drawn in the coordinates of
+	// the image so that it can be kept and
+	// restored independently of the context.
+	w, h := c.ctx.Width(), c.ctx.Height()
+	mc := gg.NewContext(w, h)
+	c.devicePath(mc, p)
+	if c.context().rule == vg.EvenOdd {
+		mc.SetFillRuleEvenOdd()
+	}
+	mc.SetColor(color.Black)
+	mc.Fill()
+	clip := mc.AsMask()
+
+	mask := clip
+	if cur := c.context().clip; cur != nil {
+		mask = image.NewAlpha(clip.Bounds())
+		draw.DrawMask(mask, mask.Bounds(), clip, image.ZP, cur, image.ZP, draw.Over)
+	}
+	c.context().clip = mask
+	c.ctx.SetMask(mask)
 }
 
 func (c *Canvas) Stroke(p vg.Path) {
-	if c.width <= 0 {
+	if c.context().width <= 0 {
 		return
 	}
+	// Closed subpaths keep their capped start
+	// with the default join, as gg draws them.
+	if c.context().join != vg.DefaultJoin {
+		p = closeJoins(p)
+	}
 	c.outline(p)
 	c.ctx.Stroke()
+	if c.context().join == vg.MiterJoin && !c.context().dashed {
+		c.miters(p)
+	}
+}
+
+// closeJoins returns the path p with closed subpaths that start with
+// a line restarted at the middle of that line, since the gg context
+// caps the ends of closed subpaths instead of joining them.
+func closeJoins(p vg.Path) vg.Path {
+	var (
+		out   vg.Path
+		start int
+	)
+	for i, comp := range p {
+		switch comp.Type {
+		case vg.MoveComp:
+			start = len(out)
+			out = append(out, comp)
+		case vg.CloseComp:
+			sub := out[start:]
+			if len(sub) < 2 || sub[0].Type != vg.MoveComp || sub[1].Type != vg.LineComp {
+				out = append(out, comp)
+				continue
+			}
+			from := sub[0].Pos
+			mid := from.Add(sub[1].Pos.Sub(from).Scale(0.5))
+			out = append(out,
+				vg.PathComp{Type: vg.LineComp, Pos: from},
+				vg.PathComp{Type: vg.LineComp, Pos: mid},
+			)
+			out[start].Pos = mid
+			if i+1 < len(p) && p[i+1].Type != vg.MoveComp {
+				// The current point after a close is
+				// the start of the closed subpath.
+				start = len(out)
+				out = append(out, vg.PathComp{Type: vg.MoveComp, Pos: from})
+			}
+		default:
+			out = append(out, comp)
+		}
+	}
+	return out
+}
+
+// miters fills the tips of the miter joins of the path p beyond the
+// bevel joins drawn by the gg context. The tips are computed in the
+// coordinates of the image, in which the line width is given. Joins
+// within curves are left beveled.
+func (c *Canvas) miters(p vg.Path) {
+	dpi := c.DPI()
+	hw := c.context().width.Dots(dpi) / 2
+	limit := c.context().limit
+
+	// dev returns the point pt in the coordinates of the
+	// image, and dir returns the unit direction in the
+	// coordinates of the image of the vector d at pt.
+	dev := func(pt vg.Point) (x, y float64) {
+		return c.ctx.TransformPoint(pt.X.Dots(dpi), pt.Y.Dots(dpi))
+	}
+	dir := func(pt, d vg.Point) (x, y float64) {
+		x0, y0 := dev(pt)
+		x1, y1 := dev(pt.Add(d))
+		x, y = x1-x0, y1-y0
+		l := math.Hypot(x, y)
+		return x / l, y / l
+	}
+
+	var tips [][3][2]float64
+	join := func(pt, in, out vg.Point) {
+		ix, iy := dir(pt, in)
+		ox, oy := dir(pt, out)
+		cos := ix*ox + iy*oy
+		cross := ix*oy - iy*ox
+		if math.Abs(cross) < 1e-9 || math.Sqrt(2/(1+cos)) > limit {
+			return
+		}
+		// The normals point to the outer side of the corner.
+		n0x, n0y, n1x, n1y := iy, -ix, oy, -ox
+		if cross < 0 {
+			n0x, n0y, n1x, n1y = -n0x, -n0y, -n1x, -n1y
+		}
+		x, y := dev(pt)
+		m := hw / (1 + cos)
+		tips = append(tips, [3][2]float64{
+			{x + hw*n0x, y + hw*n0y},
+			{x + m*(n0x+n1x), y + m*(n0y+n1y)},
+			{x + hw*n1x, y + hw*n1y},
+		})
+	}
+
+	var (
+		cur, start  vg.Point
+		first, last vg.Point
+	)
+	// segment adds a piece of the path starting at cur with
+	// the tangents t0 at its start and t1 at its end. Pieces
+	// with negligible tangents are left out.
+	segment := func(t0, t1 vg.Point) {
+		const eps = 1e-9
+		if t0.Dot(t0) < eps || t1.Dot(t1) < eps {
+			return
+		}
+		if last != (vg.Point{}) {
+			join(cur, last, t0)
+		} else {
+			first = t0
+		}
+		last = t1
+	}
+	for _, comp := range p {
+		switch comp.Type {
+		case vg.MoveComp:
+			cur, start = comp.Pos, comp.Pos
+			first, last = vg.Point{}, vg.Point{}
+
+		case vg.LineComp:
+			d := comp.Pos.Sub(cur)
+			segment(d, d)
+			cur = comp.Pos
+
+		case vg.ArcComp:
+			sin0, cos0 := math.Sincos(comp.Start)
+			sin1, cos1 := math.Sincos(comp.Start + comp.Angle)
+			r := comp.Radius
+			p0 := comp.Pos.Add(vg.Point{X: r * vg.Length(cos0), Y: r * vg.Length(sin0)})
+			p1 := comp.Pos.Add(vg.Point{X: r * vg.Length(cos1), Y: r * vg.Length(sin1)})
+			d := p0.Sub(cur)
+			segment(d, d)
+			cur = p0
+			if comp.Angle != 0 {
+				s := vg.Length(math.Copysign(1, comp.Angle))
+				segment(vg.Point{X: -s * vg.Length(sin0), Y: s * vg.Length(cos0)},
+					vg.Point{X: -s * vg.Length(sin1), Y: s * vg.Length(cos1)})
+			}
+			cur = p1
+
+		case vg.CurveComp:
+			pts := append(append([]vg.Point{cur}, comp.Control...), comp.Pos)
+			var t0, t1 vg.Point
+			for i := 1; i < len(pts) && t0 == (vg.Point{}); i++ {
+				t0 = pts[i].Sub(pts[0])
+			}
+			for i := len(pts) - 2; i >= 0 && t1 == (vg.Point{}); i-- {
+				t1 = pts[len(pts)-1].Sub(pts[i])
+			}
+			segment(t0, t1)
+			cur = comp.Pos
+
+		case vg.CloseComp:
+			d := start.Sub(cur)
+			segment(d, d)
+			if first != (vg.Point{}) && last != (vg.Point{}) {
+				join(start, last, first)
+			}
+			cur = start
+			first, last = vg.Point{}, vg.Point{}
+		}
+	}
+	if len(tips) == 0 {
+		return
+	}
+
+	c.ctx.Push()
+	c.ctx.Identity()
+	c.ctx.SetFillRuleWinding()
+	for _, t := range tips {
+		c.ctx.MoveTo(t[0][0], t[0][1])
+		c.ctx.LineTo(t[1][0], t[1][1])
+		c.ctx.LineTo(t[2][0], t[2][1])
+		c.ctx.ClosePath()
+	}
+	c.ctx.Fill()
+	c.ctx.Pop()
 }
 
 func (c *Canvas) Fill(p vg.Path) {
@@ -242,6 +525,43 @@ func (c *Canvas) Fill(p vg.Path) {
 	c.ctx.Fill()
 }
 
+// FillGradient fills the path with the gradient g,
+// implementing the vg.GradientFiller interface.
+func (c *Canvas) FillGradient(p vg.Path, g vg.Gradient) {
+	if g.Kind == vg.NoGradient || len(g.Stops) == 0 {
+		return
+	}
+	// The gradients of gg are placed in the
+	// coordinates of the image.
+	x0, y0 := c.ctx.TransformPoint(g.Start.X.Dots(c.DPI()), g.Start.Y.Dots(c.DPI()))
+	x1, y1 := c.ctx.TransformPoint(g.End.X.Dots(c.DPI()), g.End.Y.Dots(c.DPI()))
+	var pat gg.Pattern
+	switch {
+	case g.Start == g.End:
+		pat = gg.NewSolidPattern(g.At(math.Inf(1)))
+	case g.Kind == vg.LinearGradient:
+		pat = gg.NewLinearGradient(x0, y0, x1, y1)
+	case g.Kind == vg.RadialGradient:
+		pat = gg.NewRadialGradient(x0, y0, 0, x0, y0, math.Hypot(x1-x0, y1-y0))
+	default:
+		panic("vgimg: unknown gradient kind")
+	}
+	if grad, ok := pat.(gg.Gradient); ok {
+		for _, s := range g.Stops {
+			clr := s.Color
+			if clr == nil {
+				clr = color.Black
+			}
+			grad.AddColorStop(s.Offset, clr)
+		}
+	}
+
+	c.outline(p)
+	c.ctx.SetFillStyle(pat)
+	c.ctx.Fill()
+	c.ctx.SetColor(c.context().color)
+}
+
 func (c *Canvas) outline(p vg.Path) {
 	for _, comp := range p {
 		switch comp.Type {
@@ -283,6 +603,80 @@ func (c *Canvas) outline(p vg.Path) {
 	}
 }
 
+// devicePath adds the path p to the context dc, transformed to the
+// coordinates of the image. Arcs are approximated by cubic curves.
+func (c *Canvas) devicePath(dc *gg.Context, p vg.Path) {
+	dpi := c.DPI()
+	pt := func(p vg.Point) (x, y float64) {
+		return c.ctx.TransformPoint(p.X.Dots(dpi), p.Y.Dots(dpi))
+	}
+	var cur bool
+	for _, comp := range p {
+		switch comp.Type {
+		case vg.MoveComp:
+			dc.MoveTo(pt(comp.Pos))
+			cur = true
+
+		case vg.LineComp:
+			dc.LineTo(pt(comp.Pos))
+			cur = true
+
+		case vg.ArcComp:
+			// Each curve spans at most a quarter turn.
+			n := math.Ceil(math.Abs(comp.Angle) / (math.Pi / 2))
+			if n < 1 {
+				n = 1
+			}
+			da := comp.Angle / n
+			k := vg.Length(4.0 / 3 * math.Tan(da/4))
+			r := comp.Radius
+			at := func(a float64) (vg.Point, vg.Point) {
+				sin, cos := math.Sincos(a)
+				p := vg.Point{X: comp.Pos.X + r*vg.Length(cos), Y: comp.Pos.Y + r*vg.Length(sin)}
+				d := vg.Point{X: -r * vg.Length(sin), Y: r * vg.Length(cos)}
+				return p, d
+			}
+			start, d0 := at(comp.Start)
+			if cur {
+				dc.LineTo(pt(start))
+			} else {
+				dc.MoveTo(pt(start))
+			}
+			for i := 1; i <= int(n); i++ {
+				end, d1 := at(comp.Start + float64(i)*da)
+				x1, y1 := pt(start.Add(d0.Scale(k)))
+				x2, y2 := pt(end.Sub(d1.Scale(k)))
+				x3, y3 := pt(end)
+				dc.CubicTo(x1, y1, x2, y2, x3, y3)
+				start, d0 = end, d1
+			}
+			cur = true
+
+		case vg.CurveComp:
+			switch len(comp.Control) {
+			case 1:
+				x1, y1 := pt(comp.Control[0])
+				x2, y2 := pt(comp.Pos)
+				dc.QuadraticTo(x1, y1, x2, y2)
+			case 2:
+				x1, y1 := pt(comp.Control[0])
+				x2, y2 := pt(comp.Control[1])
+				x3, y3 := pt(comp.Pos)
+				dc.CubicTo(x1, y1, x2, y2, x3, y3)
+			default:
+				panic("vgimg: invalid number of control points")
+			}
+			cur = true
+
+		case vg.CloseComp:
+			dc.ClosePath()
+
+		default:
+			panic(fmt.Sprintf("Unknown path component: %d", comp.Type))
+		}
+	}
+}
+
 // DPI returns the resolution of the receiver in pixels per inch.
 func (c *Canvas) DPI() float64 {
 	return float64(c.dpi)
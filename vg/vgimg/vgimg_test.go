@@ -139,3 +139,154 @@ func TestIssue540(t *testing.T) {
 		t.Fatalf("images differ")
 	}
 }
+
+func TestFillGradient(t *testing.T) {
+	red := color.NRGBA{R: 255, A: 255}
+	blue := color.NRGBA{B: 255, A: 255}
+	stops := []vg.GradientStop{{Offset: 0, Color: red}, {Offset: 1, Color: blue}}
+	box := vg.Rectangle{Max: vg.Point{X: 100, Y: 100}}.Path()
+
+	for _, test := range []struct {
+		g    vg.Gradient
+		x, y int
+		want color.Color
+	}{
+		// Gradients are placed in the coordinates of
+		// the canvas, with Y pointing up.
+		{
+			g: vg.Gradient{Kind: vg.LinearGradient, End: vg.Point{Y: 100}, Stops: stops},
+			x: 50, y: 99, want: red,
+		},
+		{
+			g: vg.Gradient{Kind: vg.LinearGradient, End: vg.Point{Y: 100}, Stops: stops},
+			x: 50, y: 0, want: blue,
+		},
+		{
+			g: vg.Gradient{Kind: vg.RadialGradient, Start: vg.Point{X: 50, Y: 50}, End: vg.Point{X: 90, Y: 50}, Stops: stops},
+			x: 50, y: 50, want: red,
+		},
+		{
+			g: vg.Gradient{Kind: vg.RadialGradient, Start: vg.Point{X: 50, Y: 50}, End: vg.Point{X: 90, Y: 50}, Stops: stops},
+			x: 0, y: 0, want: blue,
+		},
+	} {
+		c := vgimg.NewWith(vgimg.UseWH(100, 100), vgimg.UseDPI(72))
+		c.FillGradient(box, test.g)
+		got := color.NRGBAModel.Convert(c.Image().At(test.x, test.y)).(color.NRGBA)
+		want := color.NRGBAModel.Convert(test.want).(color.NRGBA)
+		if diff(got.R, want.R) > 8 || diff(got.G, want.G) > 8 || diff(got.B, want.B) > 8 || got.A != want.A {
+			t.Errorf("unexpected color of gradient kind %d at (%d,%d): got:%v want:%v", test.g.Kind, test.x, test.y, got, want)
+		}
+	}
+}
+
+func diff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+func TestClip(t *testing.T) {
+	c := vgimg.NewWith(vgimg.UseWH(100, 100), vgimg.UseDPI(72), vgimg.UseBackgroundColor(color.White))
+	all := vg.Rectangle{Max: vg.Point{X: 100, Y: 100}}.Path()
+	red := color.NRGBA{R: 255, A: 255}
+	blue := color.NRGBA{B: 255, A: 255}
+
+	c.Push()
+	c.Clip(vg.Rectangle{Max: vg.Point{X: 50, Y: 100}}.Path())
+	c.SetColor(blue)
+	c.Fill(all)
+	c.Push()
+	c.Clip(vg.Rectangle{Max: vg.Point{X: 100, Y: 50}}.Path())
+	c.SetColor(red)
+	c.Fill(all)
+	c.Pop()
+	c.Pop()
+
+	for _, test := range []struct {
+		x, y int
+		want color.Color
+	}{
+		// Image coordinates have Y pointing down.
+		{x: 25, y: 75, want: red},
+		{x: 25, y: 25, want: blue},
+		{x: 75, y: 75, want: color.White},
+		{x: 75, y: 25, want: color.White},
+	} {
+		got := color.NRGBAModel.Convert(c.Image().At(test.x, test.y))
+		want := color.NRGBAModel.Convert(test.want)
+		if got != want {
+			t.Errorf("unexpected color at (%d,%d): got:%v want:%v", test.x, test.y, got, want)
+		}
+	}
+
+	// The clipping region is restored by Pop.
+	c.SetColor(red)
+	c.Fill(all)
+	if got := color.NRGBAModel.Convert(c.Image().At(75, 25)); got != color.Color(red) {
+		t.Errorf("unexpected color after Pop: got:%v want:%v", got, red)
+	}
+}
+
+func TestFillRule(t *testing.T) {
+	red := color.NRGBA{R: 255, A: 255}
+	var p vg.Path
+	for _, r := range []vg.Rectangle{
+		{Min: vg.Point{X: 10, Y: 10}, Max: vg.Point{X: 90, Y: 90}},
+		{Min: vg.Point{X: 30, Y: 30}, Max: vg.Point{X: 70, Y: 70}},
+	} {
+		p = append(p, r.Path()...)
+	}
+	for _, test := range []struct {
+		rule vg.FillRule
+		want color.Color
+	}{
+		{rule: vg.NonZero, want: red},
+		{rule: vg.EvenOdd, want: color.White},
+	} {
+		c := vgimg.NewWith(vgimg.UseWH(100, 100), vgimg.UseDPI(72), vgimg.UseBackgroundColor(color.White))
+		c.SetColor(red)
+		c.SetFillRule(test.rule)
+		c.Fill(p)
+		if got := color.NRGBAModel.Convert(c.Image().At(20, 20)); got != color.Color(red) {
+			t.Errorf("unexpected color of outer ring with rule %d: got:%v want:%v", test.rule, got, red)
+		}
+		got := color.NRGBAModel.Convert(c.Image().At(50, 50))
+		if want := color.NRGBAModel.Convert(test.want); got != want {
+			t.Errorf("unexpected color of hole with rule %d: got:%v want:%v", test.rule, got, want)
+		}
+	}
+}
+
+func TestLineJoin(t *testing.T) {
+	red := color.NRGBA{R: 255, A: 255}
+	var p vg.Path
+	p.Move(vg.Point{X: 10, Y: 50})
+	p.Line(vg.Point{X: 50, Y: 50})
+	p.Line(vg.Point{X: 50, Y: 10})
+	for _, test := range []struct {
+		join  vg.LineJoin
+		limit float64
+		want  color.Color
+	}{
+		{join: vg.DefaultJoin, limit: vg.DefaultMiterLimit, want: color.White},
+		{join: vg.MiterJoin, limit: vg.DefaultMiterLimit, want: red},
+		{join: vg.MiterJoin, limit: 1.2, want: color.White},
+		{join: vg.BevelJoin, limit: vg.DefaultMiterLimit, want: color.White},
+		{join: vg.RoundJoin, limit: vg.DefaultMiterLimit, want: color.White},
+	} {
+		c := vgimg.NewWith(vgimg.UseWH(100, 100), vgimg.UseDPI(72), vgimg.UseBackgroundColor(color.White))
+		c.SetColor(red)
+		c.SetLineWidth(20)
+		c.SetLineJoin(test.join)
+		c.SetMiterLimit(test.limit)
+		c.Stroke(p)
+		// The outer corner of the miter is at (60, 60),
+		// which is (60, 40) in image coordinates.
+		got := color.NRGBAModel.Convert(c.Image().At(58, 41))
+		if want := color.NRGBAModel.Convert(test.want); got != want {
+			t.Errorf("unexpected color at corner with join %d and limit %v: got:%v want:%v", test.join, test.limit, got, want)
+		}
+	}
+}
@@ -50,6 +50,10 @@ type context struct {
 	fill  color.Color
 	line  color.Color
 	width vg.Length
+	cap   vg.LineCap
+	join  vg.LineJoin
+	limit float64
+	rule  vg.FillRule
 }
 
 // New creates a new PDF Canvas.
@@ -63,7 +67,7 @@ func New(w, h vg.Length) *Canvas {
 		w:     w,
 		h:     h,
 		dpi:   DPI,
-		stack: make([]context, 1),
+		stack: []context{{limit: vg.DefaultMiterLimit}},
 		fonts: make(map[vg.Font]struct{}),
 		embed: true,
 	}
@@ -107,6 +111,66 @@ func (c *Canvas) SetLineDash(dashes []vg.Length, offs vg.Length) {
 	c.doc.SetDashPattern(ds, c.unit(offs))
 }
 
+// SetLineCap implements the vg.Canvas.SetLineCap method.
+func (c *Canvas) SetLineCap(lc vg.LineCap) {
+	if c.context().cap == lc {
+		return
+	}
+	c.context().cap = lc
+	switch lc {
+	case vg.ButtCap:
+		c.doc.SetLineCapStyle("butt")
+	case vg.RoundCap:
+		c.doc.SetLineCapStyle("round")
+	case vg.SquareCap:
+		c.doc.SetLineCapStyle("square")
+	default:
+		panic(fmt.Sprintf("vgpdf: unknown line cap %d", lc))
+	}
+}
+
+// SetLineJoin implements the vg.Canvas.SetLineJoin method.
+func (c *Canvas) SetLineJoin(lj vg.LineJoin) {
+	if c.context().join == lj {
+		return
+	}
+	c.context().join = lj
+	switch lj {
+	case vg.DefaultJoin, vg.MiterJoin:
+		c.doc.SetLineJoinStyle("miter")
+	case vg.RoundJoin:
+		c.doc.SetLineJoinStyle("round")
+	case vg.BevelJoin:
+		c.doc.SetLineJoinStyle("bevel")
+	default:
+		panic(fmt.Sprintf("vgpdf: unknown line join %d", lj))
+	}
+}
+
+// SetMiterLimit implements the vg.Canvas.SetMiterLimit method.
+func (c *Canvas) SetMiterLimit(limit float64) {
+	limit = math.Max(limit, 1)
+	if c.context().limit == limit {
+		return
+	}
+	c.context().limit = limit
+	c.doc.RawWriteStr(fmt.Sprintf("%.2f M", limit))
+}
+
+// SetFillRule implements the vg.Canvas.SetFillRule method.
+func (c *Canvas) SetFillRule(rule vg.FillRule) {
+	c.context().rule = rule
+}
+
+// evenOdd returns the suffix of the PDF fill and
+// clip operators using the current fill rule.
+func (c *Canvas) evenOdd() string {
+	if c.context().rule == vg.EvenOdd {
+		return "*"
+	}
+	return ""
+}
+
 func (c *Canvas) SetColor(clr color.Color) {
 	if clr == nil {
 		clr = color.Black
@@ -143,6 +207,11 @@ func (c *Canvas) Pop() {
 	c.stack = c.stack[:len(c.stack)-1]
 }
 
+// Clip implements the vg.Canvas.Clip method.
+func (c *Canvas) Clip(p vg.Path) {
+	c.pdfPath(p, "W"+c.evenOdd()+" n")
+}
+
 func (c *Canvas) Stroke(p vg.Path) {
 	if c.context().width > 0 {
 		c.pdfPath(p, "D")
@@ -150,7 +219,126 @@ func (c *Canvas) Stroke(p vg.Path) {
 }
 
 func (c *Canvas) Fill(p vg.Path) {
-	c.pdfPath(p, "F")
+	c.pdfPath(p, "F"+c.evenOdd())
+}
+
+// FillHatch fills the path with the hatch pattern h, implementing the
+// vg.HatchFiller interface. gofpdf cannot write PDF tiling patterns, so
+// the pattern is drawn by vg.DrawHatch over the bounds of the path and
+// clipped to the exact outline of the path, curves included.
+func (c *Canvas) FillHatch(p vg.Path, h vg.Hatch) {
+	if h.Pattern == vg.NoHatch || h.Spacing <= 0 || h.Width <= 0 || len(p) == 0 {
+		return
+	}
+	c.Push()
+	c.pdfPath(p, "W* n")
+	vg.DrawHatch(c, pathBounds(p).Path(), h)
+	c.Pop()
+}
+
+// FillGradient fills the path with the gradient g, implementing the
+// vg.GradientFiller interface. Linear gradients are drawn with a PDF
+// shading between each pair of neighboring stops, and radial gradients
+// with narrow bands of flat color. The opacity of the stops of linear
+// gradients is ignored.
+func (c *Canvas) FillGradient(p vg.Path, g vg.Gradient) {
+	if g.Kind == vg.NoGradient || len(g.Stops) == 0 || len(p) == 0 {
+		return
+	}
+	b := pathBounds(p)
+	c.Push()
+	defer c.Pop()
+	c.pdfPath(p, "W"+c.evenOdd()+" n")
+	if g.Kind != vg.LinearGradient || g.Start == g.End || len(g.Stops) == 1 {
+		vg.DrawGradient(c, b.Path(), g)
+		return
+	}
+
+	// Shadings are placed in a unit square, which
+	// is stretched over the square with its lower
+	// left corner at the lower left of the bounds
+	// of the path, with its Y axis pointing down.
+	size := b.Size()
+	side := size.X
+	if size.Y > side {
+		side = size.Y
+	}
+	if side <= 0 {
+		return
+	}
+	unit := func(pt vg.Point) (float64, float64) {
+		return float64((pt.X - b.Min.X) / side), float64((b.Min.Y + side - pt.Y) / side)
+	}
+	x, y := c.pdfPoint(b.Min)
+	w := c.unit(side)
+
+	// Each shading is clipped to the half-plane
+	// beyond its first stop, and is extended over
+	// by the following shadings.
+	dir := g.End.Sub(g.Start)
+	l := vg.Length(math.Hypot(float64(dir.X), float64(dir.Y)))
+	along := dir.Scale(1 / l)
+	across := vg.Point{X: -along.Y, Y: along.X}
+	c.doc.SetAlpha(1, "Normal")
+	var painted bool
+	for i := 0; i+1 < len(g.Stops); i++ {
+		s0, s1 := g.Stops[i], g.Stops[i+1]
+		if s1.Offset <= s0.Offset {
+			continue
+		}
+		p0 := g.Start.Add(dir.Scale(vg.Length(s0.Offset)))
+		p1 := g.Start.Add(dir.Scale(vg.Length(s1.Offset)))
+		c.Push()
+		if painted {
+			d := p0.Sub(b.Min)
+			far := 2*side + vg.Length(math.Hypot(float64(d.X), float64(d.Y)))
+			var half vg.Path
+			half.Move(p0.Add(across.Scale(far)))
+			half.Line(p0.Sub(across.Scale(far)))
+			half.Line(p0.Sub(across.Scale(far)).Add(along.Scale(far)))
+			half.Line(p0.Add(across.Scale(far)).Add(along.Scale(far)))
+			half.Close()
+			c.pdfPath(half, "W n")
+		}
+		r0, g0, b0, _ := rgba(s0.Color)
+		r1, g1, b1, _ := rgba(s1.Color)
+		x0, y0 := unit(p0)
+		x1, y1 := unit(p1)
+		c.doc.LinearGradient(x, y, w, w, r0, g0, b0, r1, g1, b1, x0, y0, x1, y1)
+		c.Pop()
+		painted = true
+	}
+	if !painted {
+		// All the stops are at the same offset.
+		vg.DrawGradient(c, b.Path(), g)
+	}
+}
+
+// pathBounds returns the bounding box of the points,
+// curve controls and arc circles of the path.
+func pathBounds(p vg.Path) vg.Rectangle {
+	min := vg.Point{X: vg.Length(math.Inf(1)), Y: vg.Length(math.Inf(1))}
+	max := vg.Point{X: vg.Length(math.Inf(-1)), Y: vg.Length(math.Inf(-1))}
+	add := func(pt vg.Point, r vg.Length) {
+		min.X = vg.Length(math.Min(float64(min.X), float64(pt.X-r)))
+		min.Y = vg.Length(math.Min(float64(min.Y), float64(pt.Y-r)))
+		max.X = vg.Length(math.Max(float64(max.X), float64(pt.X+r)))
+		max.Y = vg.Length(math.Max(float64(max.Y), float64(pt.Y+r)))
+	}
+	for _, comp := range p {
+		switch comp.Type {
+		case vg.MoveComp, vg.LineComp:
+			add(comp.Pos, 0)
+		case vg.ArcComp:
+			add(comp.Pos, comp.Radius)
+		case vg.CurveComp:
+			add(comp.Pos, 0)
+			for _, ctrl := range comp.Control {
+				add(ctrl, 0)
+			}
+		}
+	}
+	return vg.Rectangle{Min: min, Max: max}
 }
 
 func (c *Canvas) FillString(fnt vg.Font, pt vg.Point, str string) {
@@ -39,6 +39,10 @@ type Canvas struct {
 
 	buf   *bytes.Buffer
 	stack []context
+
+	// defs is the number of hatch patterns, gradients
+	// and clipping paths defined in the document.
+	defs int
 }
 
 type context struct {
@@ -46,6 +50,10 @@ type context struct {
 	dashArray  []vg.Length
 	dashOffset vg.Length
 	lineWidth  vg.Length
+	lineCap    vg.LineCap
+	lineJoin   vg.LineJoin
+	miterLimit float64
+	fillRule   vg.FillRule
 	gEnds      int
 }
 
@@ -123,6 +131,22 @@ func (c *Canvas) SetLineDash(dashes []vg.Length, offs vg.Length) {
 	c.context().dashOffset = offs
 }
 
+func (c *Canvas) SetLineCap(lc vg.LineCap) {
+	c.context().lineCap = lc
+}
+
+func (c *Canvas) SetLineJoin(lj vg.LineJoin) {
+	c.context().lineJoin = lj
+}
+
+func (c *Canvas) SetMiterLimit(limit float64) {
+	c.context().miterLimit = math.Max(limit, 1)
+}
+
+func (c *Canvas) SetFillRule(rule vg.FillRule) {
+	c.context().fillRule = rule
+}
+
 func (c *Canvas) SetColor(clr color.Color) {
 	c.context().color = clr
 }
@@ -156,6 +180,17 @@ func (c *Canvas) Pop() {
 	c.stack = c.stack[:len(c.stack)-1]
 }
 
+// Clip implements the vg.Canvas.Clip method by opening
+// a group that is clipped by an SVG clipPath.
+func (c *Canvas) Clip(path vg.Path) {
+	c.defs++
+	id := fmt.Sprintf("clip%d", c.defs)
+	fmt.Fprintf(c.buf, `<defs><clipPath id="%s"><path d="%s" %s/></clipPath></defs>`+"\n",
+		id, c.pathData(path), style(elm("clip-rule", "nonzero", fillRuleString(c))))
+	c.svg.Group(fmt.Sprintf(`clip-path="url(#%s)"`, id))
+	c.context().gEnds++
+}
+
 func (c *Canvas) Stroke(path vg.Path) {
 	if c.context().lineWidth.Points() <= 0 {
 		return
@@ -166,13 +201,97 @@ func (c *Canvas) Stroke(path vg.Path) {
 			elm("stroke-opacity", "1", opacityString(c.context().color)),
 			elm("stroke-width", "1", "%.*g", pr, c.context().lineWidth.Points()),
 			elm("stroke-dasharray", "none", dashArrayString(c)),
-			elm("stroke-dashoffset", "0", "%.*g", pr, c.context().dashOffset.Points())))
+			elm("stroke-dashoffset", "0", "%.*g", pr, c.context().dashOffset.Points()),
+			elm("stroke-linecap", "butt", lineCapString(c)),
+			elm("stroke-linejoin", "miter", lineJoinString(c)),
+			elm("stroke-miterlimit", "4", miterLimitString(c))))
 }
 
 func (c *Canvas) Fill(path vg.Path) {
 	c.svg.Path(c.pathData(path),
 		style(elm("fill", "#000000", colorString(c.context().color)),
-			elm("fill-opacity", "1", opacityString(c.context().color))))
+			elm("fill-opacity", "1", opacityString(c.context().color)),
+			elm("fill-rule", "nonzero", fillRuleString(c))))
+}
+
+// FillHatch fills the path with the hatch pattern h using an SVG
+// pattern, implementing the vg.HatchFiller interface.
+func (c *Canvas) FillHatch(path vg.Path, h vg.Hatch) {
+	if h.Pattern == vg.NoHatch || h.Spacing <= 0 || h.Width <= 0 {
+		return
+	}
+	s := h.Spacing.Points()
+	var tile, transform string
+	switch h.Pattern {
+	case vg.DiagonalHatch:
+		tile = fmt.Sprintf("M0,%.*gH%.*g", pr, s/2, pr, s)
+		transform = ` patternTransform="rotate(45)"`
+	case vg.CrossHatch:
+		tile = fmt.Sprintf("M0,%.*gH%.*gM%.*g,0V%.*g", pr, s/2, pr, s, pr, s/2, pr, s)
+	case vg.HorizontalHatch:
+		tile = fmt.Sprintf("M0,%.*gH%.*g", pr, s/2, pr, s)
+	case vg.VerticalHatch:
+		tile = fmt.Sprintf("M%.*g,0V%.*g", pr, s/2, pr, s)
+	case vg.DotHatch:
+	default:
+		panic("vgsvg: unknown hatch pattern")
+	}
+
+	c.defs++
+	id := fmt.Sprintf("hatch%d", c.defs)
+	fmt.Fprintf(c.buf, `<defs><pattern id="%s" patternUnits="userSpaceOnUse" width="%.*g" height="%.*g"%s>`,
+		id, pr, s, pr, s, transform)
+	if h.Pattern == vg.DotHatch {
+		fmt.Fprintf(c.buf, `<circle cx="%.*g" cy="%.*g" r="%.*g" %s/>`,
+			pr, s/2, pr, s/2, pr, h.Width.Points()/2,
+			style(elm("fill", "#000000", colorString(h.Color)),
+				elm("fill-opacity", "1", opacityString(h.Color))))
+	} else {
+		c.svg.Path(tile,
+			style(elm("fill", "#000000", "none"),
+				elm("stroke", "none", colorString(h.Color)),
+				elm("stroke-opacity", "1", opacityString(h.Color)),
+				elm("stroke-width", "1", "%.*g", pr, h.Width.Points())))
+	}
+	fmt.Fprintln(c.buf, "</pattern></defs>")
+
+	c.svg.Path(c.pathData(path),
+		style(elm("fill", "#000000", "url(#%s)", id),
+			elm("fill-rule", "nonzero", "evenodd")))
+}
+
+// FillGradient fills the path with the gradient g using an SVG
+// gradient, implementing the vg.GradientFiller interface.
+func (c *Canvas) FillGradient(path vg.Path, g vg.Gradient) {
+	if g.Kind == vg.NoGradient || len(g.Stops) == 0 {
+		return
+	}
+	c.defs++
+	id := fmt.Sprintf("gradient%d", c.defs)
+	var elem string
+	switch g.Kind {
+	case vg.LinearGradient:
+		elem = "linearGradient"
+		fmt.Fprintf(c.buf, `<defs><%s id="%s" gradientUnits="userSpaceOnUse" x1="%.*g" y1="%.*g" x2="%.*g" y2="%.*g">`,
+			elem, id, pr, g.Start.X.Points(), pr, g.Start.Y.Points(), pr, g.End.X.Points(), pr, g.End.Y.Points())
+	case vg.RadialGradient:
+		elem = "radialGradient"
+		d := g.End.Sub(g.Start)
+		fmt.Fprintf(c.buf, `<defs><%s id="%s" gradientUnits="userSpaceOnUse" cx="%.*g" cy="%.*g" r="%.*g">`,
+			elem, id, pr, g.Start.X.Points(), pr, g.Start.Y.Points(), pr, math.Hypot(d.X.Points(), d.Y.Points()))
+	default:
+		panic("vgsvg: unknown gradient kind")
+	}
+	for _, s := range g.Stops {
+		fmt.Fprintf(c.buf, `<stop offset="%.*g" %s/>`, pr, s.Offset,
+			style(elm("stop-color", "#000000", colorString(s.Color)),
+				elm("stop-opacity", "1", opacityString(s.Color))))
+	}
+	fmt.Fprintf(c.buf, "</%s></defs>\n", elem)
+
+	c.svg.Path(c.pathData(path),
+		style(elm("fill", "#000000", "url(#%s)", id),
+			elm("fill-rule", "nonzero", fillRuleString(c))))
 }
 
 func (c *Canvas) pathData(path vg.Path) string {
@@ -426,6 +545,55 @@ func elm(key, def, f string, vls ...interface{}) string {
 	return key + ":" + value
 }
 
+// lineCapString returns the SVG name of the current line cap.
+func lineCapString(c *Canvas) string {
+	switch lc := c.context().lineCap; lc {
+	case vg.ButtCap:
+		return "butt"
+	case vg.RoundCap:
+		return "round"
+	case vg.SquareCap:
+		return "square"
+	default:
+		panic(fmt.Sprintf("vgsvg: unknown line cap %d", lc))
+	}
+}
+
+// lineJoinString returns the SVG name of the current line join.
+func lineJoinString(c *Canvas) string {
+	switch lj := c.context().lineJoin; lj {
+	case vg.DefaultJoin, vg.MiterJoin:
+		return "miter"
+	case vg.RoundJoin:
+		return "round"
+	case vg.BevelJoin:
+		return "bevel"
+	default:
+		panic(fmt.Sprintf("vgsvg: unknown line join %d", lj))
+	}
+}
+
+// miterLimitString returns the current miter limit, or the
+// SVG default if the current line join is not a miter join.
+func miterLimitString(c *Canvas) string {
+	if c.context().lineJoin != vg.MiterJoin {
+		return "4"
+	}
+	return fmt.Sprintf("%.*g", pr, c.context().miterLimit)
+}
+
+// fillRuleString returns the SVG name of the current fill rule.
+func fillRuleString(c *Canvas) string {
+	switch rule := c.context().fillRule; rule {
+	case vg.NonZero:
+		return "nonzero"
+	case vg.EvenOdd:
+		return "evenodd"
+	default:
+		panic(fmt.Sprintf("vgsvg: unknown fill rule %d", rule))
+	}
+}
+
 // dashArrayString returns a string representing the
 // dash array specification.
 func dashArrayString(c *Canvas) string {
@@ -6,8 +6,10 @@ package vgsvg_test
 
 import (
 	"bytes"
+	"image/color"
 	"io/ioutil"
 	"log"
+	"strings"
 	"testing"
 
 	"github.com/gshk/plot"
@@ -123,3 +125,131 @@ func TestHtmlEscape(t *testing.T) {
 		t.Fatalf("images differ:\ngot:\n%s\nwant:\n%s\n", b.Bytes(), want)
 	}
 }
+
+func TestFillHatch(t *testing.T) {
+	c := vgsvg.New(10, 10)
+	var p vg.Path
+	p.Move(vg.Point{X: 1, Y: 1})
+	p.Line(vg.Point{X: 9, Y: 1})
+	p.Line(vg.Point{X: 9, Y: 9})
+	p.Close()
+	c.FillHatch(p, vg.Hatch{Pattern: vg.DiagonalHatch, Spacing: 2, Width: 0.5})
+	c.FillHatch(p, vg.Hatch{Pattern: vg.DotHatch, Spacing: 2, Width: 0.5})
+	c.FillHatch(p, vg.Hatch{})
+
+	b := new(bytes.Buffer)
+	if _, err := c.WriteTo(b); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		`<pattern id="hatch1"`,
+		`patternTransform="rotate(45)"`,
+		`fill:url(#hatch1)`,
+		`<pattern id="hatch2"`,
+		`<circle `,
+		`fill:url(#hatch2)`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in SVG output:\n%s", want, got)
+		}
+	}
+	if n := strings.Count(got, "<pattern"); n != 2 {
+		t.Errorf("unexpected number of patterns: got:%d want:2", n)
+	}
+}
+
+func TestFillGradient(t *testing.T) {
+	c := vgsvg.New(10, 10)
+	var p vg.Path
+	p.Move(vg.Point{X: 1, Y: 1})
+	p.Line(vg.Point{X: 9, Y: 1})
+	p.Line(vg.Point{X: 9, Y: 9})
+	p.Close()
+	stops := []vg.GradientStop{
+		{Offset: 0, Color: color.NRGBA{R: 255, A: 255}},
+		{Offset: 1, Color: color.NRGBA{B: 255, A: 128}},
+	}
+	c.FillGradient(p, vg.Gradient{Kind: vg.LinearGradient, End: vg.Point{X: 10}, Stops: stops})
+	c.FillGradient(p, vg.Gradient{Kind: vg.RadialGradient, Start: vg.Point{X: 5, Y: 5}, End: vg.Point{X: 8, Y: 9}, Stops: stops})
+	c.FillGradient(p, vg.Gradient{})
+
+	b := new(bytes.Buffer)
+	if _, err := c.WriteTo(b); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		`<linearGradient id="gradient1" gradientUnits="userSpaceOnUse" x1="0" y1="0" x2="10" y2="0">`,
+		`<stop offset="0" style="stop-color:#FF0000"/>`,
+		`<stop offset="1" style="stop-color:#0000FF;stop-opacity:0.50196"/>`,
+		`fill:url(#gradient1)`,
+		`<radialGradient id="gradient2" gradientUnits="userSpaceOnUse" cx="5" cy="5" r="5">`,
+		`fill:url(#gradient2)`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in SVG output:\n%s", want, got)
+		}
+	}
+	if n := strings.Count(got, "Gradient id="); n != 2 {
+		t.Errorf("unexpected number of gradients: got:%d want:2", n)
+	}
+}
+
+func TestClip(t *testing.T) {
+	c := vgsvg.New(10, 10)
+	clip := vg.Rectangle{Min: vg.Point{X: 1, Y: 1}, Max: vg.Point{X: 5, Y: 5}}.Path()
+	c.Push()
+	c.Clip(clip)
+	c.Fill(vg.Rectangle{Max: vg.Point{X: 10, Y: 10}}.Path())
+	c.Pop()
+
+	b := new(bytes.Buffer)
+	if _, err := c.WriteTo(b); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		`<clipPath id="clip1"><path d="M1,1L5,1L5,5L1,5Z" /></clipPath>`,
+		`<g clip-path="url(#clip1)" >`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in SVG output:\n%s", want, got)
+		}
+	}
+	if open, close := strings.Count(got, "<g"), strings.Count(got, "</g>"); open != close {
+		t.Errorf("unbalanced groups: got %d opened and %d closed", open, close)
+	}
+}
+
+func TestLineStyle(t *testing.T) {
+	c := vgsvg.New(10, 10)
+	var p vg.Path
+	p.Move(vg.Point{X: 1, Y: 1})
+	p.Line(vg.Point{X: 5, Y: 9})
+	p.Line(vg.Point{X: 9, Y: 1})
+	c.SetLineCap(vg.RoundCap)
+	c.SetLineJoin(vg.BevelJoin)
+	c.Stroke(p)
+	c.SetLineCap(vg.ButtCap)
+	c.SetLineJoin(vg.MiterJoin)
+	c.SetMiterLimit(3)
+	c.Stroke(p)
+	c.SetFillRule(vg.EvenOdd)
+	c.Fill(p)
+
+	b := new(bytes.Buffer)
+	if _, err := c.WriteTo(b); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		`stroke-linecap:round;stroke-linejoin:bevel"`,
+		`stroke-miterlimit:3"`,
+		`fill-rule:evenodd"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in SVG output:\n%s", want, got)
+		}
+	}
+}
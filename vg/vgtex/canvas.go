@@ -47,6 +47,7 @@ type Canvas struct {
 	// .tex file that can be fed to, e.g., pdflatex.
 	document bool
 	id       int64 // id is a unique identifier for this canvas
+	shadings int   // shadings is the number of declared shadings
 }
 
 type context struct {
@@ -54,6 +55,20 @@ type context struct {
 	dashArray  []vg.Length
 	dashOffset vg.Length
 	linew      vg.Length
+	style      pgfStyle
+
+	// pgf is the style last written to the
+	// output in the current PGF scope.
+	pgf pgfStyle
+}
+
+// pgfStyle is the part of the state of the canvas that
+// is kept by PGF until the end of the current scope.
+type pgfStyle struct {
+	cap   vg.LineCap
+	join  vg.LineJoin
+	limit float64
+	rule  vg.FillRule
 }
 
 // New returns a new LaTeX canvas.
@@ -83,7 +98,9 @@ func newCanvas(w, h vg.Length, document bool) *Canvas {
 	}
 	c.wtex("")
 	c.wtex(`\begin{pgfpicture}`)
-	c.stack = make([]context, 1)
+	// The initial style of PGF.
+	def := pgfStyle{limit: vg.DefaultMiterLimit}
+	c.stack = []context{{style: def, pgf: def}}
 	vg.Initialize(c)
 	return c
 }
@@ -108,6 +125,26 @@ func (c *Canvas) SetLineDash(pattern []vg.Length, offset vg.Length) {
 	c.context().dashOffset = offset
 }
 
+// SetLineCap implements the vg.Canvas.SetLineCap method.
+func (c *Canvas) SetLineCap(lc vg.LineCap) {
+	c.context().style.cap = lc
+}
+
+// SetLineJoin implements the vg.Canvas.SetLineJoin method.
+func (c *Canvas) SetLineJoin(lj vg.LineJoin) {
+	c.context().style.join = lj
+}
+
+// SetMiterLimit implements the vg.Canvas.SetMiterLimit method.
+func (c *Canvas) SetMiterLimit(limit float64) {
+	c.context().style.limit = math.Max(limit, 1)
+}
+
+// SetFillRule implements the vg.Canvas.SetFillRule method.
+func (c *Canvas) SetFillRule(rule vg.FillRule) {
+	c.context().style.rule = rule
+}
+
 // SetColor implements the vg.Canvas.SetColor method.
 func (c *Canvas) SetColor(clr color.Color) {
 	c.context().color = clr
@@ -142,6 +179,14 @@ func (c *Canvas) Pop() {
 	c.wtex("")
 }
 
+// Clip implements the vg.Canvas.Clip method.
+func (c *Canvas) Clip(p vg.Path) {
+	c.wpgfStyle()
+	c.wpath(p)
+	c.wtex(`\pgfusepath{clip}`)
+	c.wtex("")
+}
+
 // Stroke implements the vg.Canvas.Stroke method.
 func (c *Canvas) Stroke(p vg.Path) {
 	if c.context().linew <= 0 {
@@ -161,6 +206,86 @@ func (c *Canvas) Fill(p vg.Path) {
 	c.wtex("")
 }
 
+// FillGradient implements the vg.GradientFiller interface using PGF
+// shadings. The opacity of the stops is ignored.
+func (c *Canvas) FillGradient(p vg.Path, g vg.Gradient) {
+	if g.Kind == vg.NoGradient || len(g.Stops) == 0 {
+		return
+	}
+	d := g.End.Sub(g.Start)
+	l := vg.Length(math.Hypot(float64(d.X), float64(d.Y)))
+	if l == 0 {
+		c.Push()
+		c.SetColor(g.At(math.Inf(1)))
+		c.Fill(p)
+		c.Pop()
+		return
+	}
+
+	// PGF stretches the middle 50bp square of a 100bp
+	// shading over the bounding box of the shaded path.
+	// The shaded path is a square centered on the
+	// gradient that covers the canvas, clipped to p.
+	half := c.w + c.h
+	scale := float64(2 * half / 50)
+
+	c.shadings++
+	name := fmt.Sprintf("gonum%dshading%d", c.id, c.shadings)
+	var (
+		center vg.Point
+		angle  float64
+		bp     func(t float64) float64
+		end    float64
+	)
+	switch g.Kind {
+	case vg.LinearGradient:
+		center = g.Start.Add(d.Scale(0.5))
+		angle = math.Atan2(float64(d.Y), float64(d.X))
+		bp = func(t float64) float64 { return 50 + (t-0.5)*float64(l)/scale }
+		end = 100
+	case vg.RadialGradient:
+		center = g.Start
+		bp = func(t float64) float64 { return t * float64(l) / scale }
+		end = 50
+	default:
+		panic("vgtex: unknown gradient kind")
+	}
+	offset := func(pos float64) float64 { return (pos - bp(0)) / (bp(1) - bp(0)) }
+	spec := []string{shadingColor(0, g.At(offset(0)))}
+	for _, s := range g.Stops {
+		if pos := bp(s.Offset); pos > 0 && pos < end {
+			spec = append(spec, shadingColor(pos, g.At(s.Offset)))
+		}
+	}
+	spec = append(spec, shadingColor(end, g.At(offset(end))))
+	if g.Kind == vg.LinearGradient {
+		c.wtex(`\pgfdeclarehorizontalshading{%s}{100bp}{%s}`, name, strings.Join(spec, "; "))
+	} else {
+		c.wtex(`\pgfdeclareradialshading{%s}{\pgfpointorigin}{%s}`, name, strings.Join(spec, "; "))
+	}
+
+	c.Push()
+	c.wpgfStyle()
+	c.wpath(p)
+	c.wtex(`\pgfusepath{clip}`)
+	c.wtex(`\pgfpathrectangle{\pgfpoint{%gpt}{%gpt}}{\pgfpoint{%gpt}{%gpt}}`,
+		center.X-half, center.Y-half, 2*half, 2*half)
+	c.wtex(`\pgfshadepath{%s}{%g}`, name, angle*degPerRadian)
+	c.wtex(`\pgfusepath{discard}`)
+	c.Pop()
+}
+
+// shadingColor returns the PGF shading color specification
+// of clr at the position pos in big points.
+func shadingColor(pos float64, clr color.Color) string {
+	r, g, b, a := clr.RGBA()
+	if a == 0 {
+		return fmt.Sprintf("rgb(%gbp)=(0,0,0)", pos)
+	}
+	f := 1 / float64(a)
+	return fmt.Sprintf("rgb(%gbp)=(%g,%g,%g)", pos, float64(r)*f, float64(g)*f, float64(b)*f)
+}
+
 // FillString implements the vg.Canvas.FillString method.
 func (c *Canvas) FillString(f vg.Font, pt vg.Point, text string) {
 	c.wcolor()
@@ -168,6 +293,37 @@ func (c *Canvas) FillString(f vg.Font, pt vg.Point, text string) {
 	c.wtex(`\pgftext[base,at={\pgfpoint{%gpt}{%gpt}}]{%s}`, pt.X, pt.Y, text)
 }
 
+// FillFont implements the vg.FontFiller interface.
+// The text is typeset in the size of the font, in bold for
+// bold fonts and in italics for italic and oblique fonts.
+func (c *Canvas) FillFont(f vg.Font, pt vg.Point, text string) {
+	c.wcolor()
+	pt.X += 0.5 * f.Width(text)
+	c.wtex(`\pgftext[base,at={\pgfpoint{%gpt}{%gpt}}]{\fontsize{%gpt}{%gpt}\selectfont%s %s}`,
+		pt.X, pt.Y, f.Size, f.Size, fontShape(f.Name()), text)
+}
+
+// FillMath implements the vg.MathFiller interface,
+// passing the TeX math in src through to LaTeX.
+// The formula is typeset in the font of the document.
+func (c *Canvas) FillMath(f vg.Font, pt vg.Point, src string) {
+	c.wcolor()
+	c.wtex(`\pgftext[base,left,at={\pgfpoint{%gpt}{%gpt}}]{$%s$}`, pt.X, pt.Y, src)
+}
+
+// fontShape returns the commands selecting the
+// series and the shape of the named font.
+func fontShape(name string) string {
+	var cmds string
+	if strings.Contains(name, "Bold") {
+		cmds += `\bfseries`
+	}
+	if strings.Contains(name, "Italic") || strings.Contains(name, "Oblique") {
+		cmds += `\itshape`
+	}
+	return cmds
+}
+
 // DrawImage implements the vg.Canvas.DrawImage method.
 // DrawImage will first save the image inside a PNG file and have the
 // generated LaTeX reference that file.
@@ -204,9 +360,54 @@ func (c *Canvas) wtex(s string, args ...interface{}) {
 func (c *Canvas) wstyle() {
 	c.wdash()
 	c.wlineWidth()
+	c.wpgfStyle()
 	c.wcolor()
 }
 
+// wpgfStyle writes the parts of the style that
+// differ from the style kept by PGF.
+func (c *Canvas) wpgfStyle() {
+	ctx := c.context()
+	if ctx.style.cap != ctx.pgf.cap {
+		switch ctx.style.cap {
+		case vg.ButtCap:
+			c.wtex(`\pgfsetbuttcap`)
+		case vg.RoundCap:
+			c.wtex(`\pgfsetroundcap`)
+		case vg.SquareCap:
+			c.wtex(`\pgfsetrectcap`)
+		default:
+			panic(fmt.Sprintf("vgtex: unknown line cap %d", ctx.style.cap))
+		}
+	}
+	if ctx.style.join != ctx.pgf.join {
+		switch ctx.style.join {
+		case vg.DefaultJoin, vg.MiterJoin:
+			c.wtex(`\pgfsetmiterjoin`)
+		case vg.RoundJoin:
+			c.wtex(`\pgfsetroundjoin`)
+		case vg.BevelJoin:
+			c.wtex(`\pgfsetbeveljoin`)
+		default:
+			panic(fmt.Sprintf("vgtex: unknown line join %d", ctx.style.join))
+		}
+	}
+	if ctx.style.limit != ctx.pgf.limit {
+		c.wtex(`\pgfsetmiterlimit{%g}`, ctx.style.limit)
+	}
+	if ctx.style.rule != ctx.pgf.rule {
+		switch ctx.style.rule {
+		case vg.NonZero:
+			c.wtex(`\pgfsetnonzerorule`)
+		case vg.EvenOdd:
+			c.wtex(`\pgfseteorule`)
+		default:
+			panic(fmt.Sprintf("vgtex: unknown fill rule %d", ctx.style.rule))
+		}
+	}
+	ctx.pgf = ctx.style
+}
+
 func (c *Canvas) wdash() {
 	if len(c.context().dashArray) == 0 {
 		return
@@ -5,8 +5,11 @@
 package vgtex_test
 
 import (
+	"bytes"
+	"image/color"
 	"log"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/gshk/plot"
@@ -51,3 +54,63 @@ func Example() {
 func TestTexCanvas(t *testing.T) {
 	cmpimg.CheckPlot(Example, t, "scatter.tex")
 }
+
+func TestFillGradient(t *testing.T) {
+	c := vgtex.New(10, 10)
+	box := vg.Rectangle{Max: vg.Point{X: 10, Y: 10}}.Path()
+	stops := []vg.GradientStop{
+		{Offset: 0, Color: color.White},
+		{Offset: 1, Color: color.NRGBA{R: 255, A: 255}},
+	}
+	c.FillGradient(box, vg.Gradient{Kind: vg.LinearGradient, End: vg.Point{X: 10}, Stops: stops})
+	c.FillGradient(box, vg.Gradient{Kind: vg.RadialGradient, Start: vg.Point{X: 5, Y: 5}, End: vg.Point{X: 5, Y: 8}, Stops: stops})
+
+	b := new(bytes.Buffer)
+	if _, err := c.WriteTo(b); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+	// The shaded squares are 40pt wide and their middle
+	// 50bp are stretched over 40pt, so 1pt of the
+	// gradient is 1.25bp of the shading.
+	for _, want := range []string{
+		`rgb(0bp)=(1,1,1); rgb(43.75bp)=(1,1,1); rgb(56.25bp)=(1,0,0); rgb(100bp)=(1,0,0)}`,
+		`\pgfpathrectangle{\pgfpoint{-15pt}{-20pt}}{\pgfpoint{40pt}{40pt}}`,
+		`rgb(0bp)=(1,1,1); rgb(3.75bp)=(1,0,0); rgb(50bp)=(1,0,0)}`,
+		`\pgfpathrectangle{\pgfpoint{-15pt}{-15pt}}{\pgfpoint{40pt}{40pt}}`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in output:\n%s", want, got)
+		}
+	}
+	if n := strings.Count(got, `\pgfshadepath{`); n != 2 {
+		t.Errorf("unexpected number of shaded paths: got:%d want:2", n)
+	}
+}
+
+func TestFillMarkup(t *testing.T) {
+	fnt, err := vg.MakeFont("Times-Roman", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := vgtex.New(100, 100)
+	dc := draw.New(c)
+	dc.FillText(draw.TextStyle{Font: fnt, Markup: true}, vg.Point{X: 10, Y: 10}, `x^2 \textbf{y} $\alpha$`)
+
+	b := new(bytes.Buffer)
+	if _, err := c.WriteTo(b); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		`]{x}`,
+		`]{\fontsize{7pt}{7pt}\selectfont 2}`,
+		`]{ }`,
+		`]{\fontsize{10pt}{10pt}\selectfont\bfseries y}`,
+		`]{$\alpha$}`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in output:\n%s", want, got)
+		}
+	}
+}